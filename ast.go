@@ -10,6 +10,28 @@ type Node interface {
 	String() string
 }
 
+// Comment represents a single `//` comment.
+type Comment struct {
+	Token Token
+	Text  string
+}
+
+// CommentGroup is a run of comments with no other tokens between them,
+// attached to whatever declaration immediately follows. Only populated
+// when the parser is run with the ParseComments mode.
+type CommentGroup struct {
+	List []*Comment
+}
+
+func (cg *CommentGroup) String() string {
+	var out strings.Builder
+	for _, c := range cg.List {
+		out.WriteString(c.Text)
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
 // Expression interface for all expression nodes
 type Expression interface {
 	Node
@@ -110,6 +132,64 @@ func (p *Parameter) String() string {
 	return fmt.Sprintf("%s %s", p.Type.Literal, p.Name.String())
 }
 
+// CallExpression represents a function call, e.g. add(1, 2)
+type CallExpression struct {
+	Token     Token // the '(' token
+	Function  Expression
+	Arguments []Expression
+}
+
+func (ce *CallExpression) expressionNode() {}
+func (ce *CallExpression) String() string {
+	var args []string
+	for _, a := range ce.Arguments {
+		args = append(args, a.String())
+	}
+	return fmt.Sprintf("%s(%s)", ce.Function.String(), strings.Join(args, ", "))
+}
+
+// ArrayLiteral represents array literals like [1, 2, 3]
+type ArrayLiteral struct {
+	Token    Token // the '[' token
+	Elements []Expression
+}
+
+func (al *ArrayLiteral) expressionNode() {}
+func (al *ArrayLiteral) String() string {
+	var elems []string
+	for _, e := range al.Elements {
+		elems = append(elems, e.String())
+	}
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+// HashLiteral represents hash literals like {"a": 1, "b": 2}
+type HashLiteral struct {
+	Token Token // the '{' token
+	Pairs map[Expression]Expression
+}
+
+func (hl *HashLiteral) expressionNode() {}
+func (hl *HashLiteral) String() string {
+	var pairs []string
+	for k, v := range hl.Pairs {
+		pairs = append(pairs, k.String()+": "+v.String())
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// IndexExpression represents indexing like arr[0] or hash["key"]
+type IndexExpression struct {
+	Token Token // the '[' token
+	Left  Expression
+	Index Expression
+}
+
+func (ie *IndexExpression) expressionNode() {}
+func (ie *IndexExpression) String() string {
+	return fmt.Sprintf("(%s[%s])", ie.Left.String(), ie.Index.String())
+}
+
 // TypeOfExpression represents typeof operations like typeof(x)
 type TypeOfExpression struct {
 	Token Token      // The 'typeof' token
@@ -130,6 +210,7 @@ type VariableDeclaration struct {
 	Token Token
 	Name  *Identifier
 	Value Expression
+	Doc   *CommentGroup // leading comment, set only under the ParseComments mode
 }
 
 func (vd *VariableDeclaration) statementNode() {}
@@ -290,6 +371,25 @@ func (fs *ForStatement) String() string {
 }
 
 
+// EventHandler represents a top-level reactive handler declared with
+// `on <name>(params) { ... }`. The evaluator registers these with an
+// EventLoop instead of running them immediately.
+type EventHandler struct {
+	Token  Token // the 'on' token
+	Name   string
+	Params []*Parameter
+	Body   *BlockStatement
+}
+
+func (eh *EventHandler) statementNode() {}
+func (eh *EventHandler) String() string {
+	var params []string
+	for _, p := range eh.Params {
+		params = append(params, p.String())
+	}
+	return fmt.Sprintf("on %s(%s) %s", eh.Name, strings.Join(params, ", "), eh.Body.String())
+}
+
 // FunctionStatement represents function declarations
 type FunctionStatement struct {
 	Token      Token // the return type token
@@ -297,6 +397,7 @@ type FunctionStatement struct {
 	Parameters []*Parameter
 	ReturnType Token
 	Body       *BlockStatement
+	Doc        *CommentGroup // leading comment, set only under the ParseComments mode
 }
 
 func (fs *FunctionStatement) statementNode() {}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"hash/fnv"
+	"math"
 	"strconv"
+	"strings"
 )
 
 // Object interface for all evaluated values
@@ -28,6 +31,17 @@ type Integer struct {
 
 func (i *Integer) Inspect() string { return fmt.Sprintf("%d", i.Value) }
 func (i *Integer) Type() Type      { return IntType }
+func (i *Integer) HashKey() HashKey {
+	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
+}
+
+// Float object
+type Float struct {
+	Value float64
+}
+
+func (f *Float) Inspect() string { return strconv.FormatFloat(f.Value, 'g', -1, 64) }
+func (f *Float) Type() Type      { return FloatType }
 
 // String object
 type String struct {
@@ -36,6 +50,58 @@ type String struct {
 
 func (s *String) Inspect() string { return s.Value }
 func (s *String) Type() Type      { return StringType }
+func (s *String) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write([]byte(s.Value))
+	return HashKey{Type: s.Type(), Value: h.Sum64()}
+}
+
+// HashKey is the comparable key a Hash uses internally; Inspect-able
+// objects that implement Hashable can be used as hash literal keys.
+type HashKey struct {
+	Type  Type
+	Value uint64
+}
+
+// Hashable is implemented by objects that can be used as hash keys.
+type Hashable interface {
+	HashKey() HashKey
+}
+
+// Array is an ordered, zero-indexed collection of values.
+type Array struct {
+	Elements []Object
+}
+
+func (a *Array) Type() Type { return ArrayType }
+func (a *Array) Inspect() string {
+	var elems []string
+	for _, e := range a.Elements {
+		elems = append(elems, e.Inspect())
+	}
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+// HashPair keeps the original key object alongside its value so Inspect
+// can print the key even though lookups go through HashKey.
+type HashPair struct {
+	Key   Object
+	Value Object
+}
+
+// Hash is a map keyed by Hashable objects (Integer, String, ...).
+type Hash struct {
+	Pairs map[HashKey]HashPair
+}
+
+func (h *Hash) Type() Type { return HashType }
+func (h *Hash) Inspect() string {
+	var pairs []string
+	for _, pair := range h.Pairs {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", pair.Key.Inspect(), pair.Value.Inspect()))
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
 
 // Error object
 type Error struct {
@@ -51,6 +117,325 @@ type Null struct{}
 func (n *Null) Inspect() string { return "null" }
 func (n *Null) Type() Type      { return VoidType }
 
+// Boolean object
+type Boolean struct {
+	Value bool
+}
+
+func (b *Boolean) Inspect() string { return fmt.Sprintf("%t", b.Value) }
+func (b *Boolean) Type() Type      { return BoolType }
+func (b *Boolean) HashKey() HashKey {
+	var value uint64
+	if b.Value {
+		value = 1
+	}
+	return HashKey{Type: b.Type(), Value: value}
+}
+
+// Singleton instances shared by every true/false/null result so comparisons
+// like `x == y` can rely on pointer equality instead of allocating fresh
+// objects for every boolean/null value produced during evaluation.
+var (
+	TRUE  = &Boolean{Value: true}
+	FALSE = &Boolean{Value: false}
+	NULL  = &Null{}
+)
+
+// ReturnValue wraps the value produced by a return statement so that
+// evalBlockStatement can propagate it up through nested blocks/loops and
+// have it unwrapped at the enclosing function (or program) boundary.
+type ReturnValue struct {
+	Value Object
+}
+
+func (rv *ReturnValue) Inspect() string { return rv.Value.Inspect() }
+func (rv *ReturnValue) Type() Type      { return rv.Value.Type() }
+
+// BreakObject is the sentinel produced by a break statement; loops consume
+// it and stop iterating rather than letting it escape the loop.
+type BreakObject struct{}
+
+func (b *BreakObject) Inspect() string { return "break" }
+func (b *BreakObject) Type() Type      { return VoidType }
+
+// ContinueObject is the sentinel produced by a continue statement; loops
+// consume it to skip to the next iteration.
+type ContinueObject struct{}
+
+func (c *ContinueObject) Inspect() string { return "continue" }
+func (c *ContinueObject) Type() Type      { return VoidType }
+
+var (
+	BREAK_SIGNAL    = &BreakObject{}
+	CONTINUE_SIGNAL = &ContinueObject{}
+)
+
+// Function is a user-defined function value. It carries the environment
+// that was active at the point of declaration so calls see proper lexical
+// closures rather than the caller's environment.
+type Function struct {
+	Parameters []*Parameter
+	Body       *BlockStatement
+	Env        *EvalEnv
+}
+
+func (f *Function) Type() Type { return FuncType }
+func (f *Function) Inspect() string {
+	var params []string
+	for _, p := range f.Parameters {
+		params = append(params, p.String())
+	}
+	return fmt.Sprintf("fn(%s) { ... }", strings.Join(params, ", "))
+}
+
+// BuiltinFunction is the Go-side signature every builtin implements.
+type BuiltinFunction func(args ...Object) Object
+
+// Builtin wraps a BuiltinFunction so it can flow through the evaluator as
+// a regular Object, the same way a user-defined Function does.
+type Builtin struct {
+	Fn BuiltinFunction
+}
+
+func (b *Builtin) Type() Type      { return FuncType }
+func (b *Builtin) Inspect() string { return "builtin function" }
+
+// builtins is the package-level registry consulted by evalIdentifier once
+// the environment lookup misses, so builtins behave like ordinary
+// identifiers bound to callable values.
+var builtins = map[string]*Builtin{
+	"len": {Fn: func(args ...Object) Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments for `len`: got=%d, want=1", len(args))
+		}
+		switch arg := args[0].(type) {
+		case *String:
+			return &Integer{Value: int64(len(arg.Value))}
+		case *Array:
+			return &Integer{Value: int64(len(arg.Elements))}
+		default:
+			return newError("argument to `len` not supported, got %s", args[0].Type())
+		}
+	}},
+	"first": {Fn: func(args ...Object) Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments for `first`: got=%d, want=1", len(args))
+		}
+		arr, ok := args[0].(*Array)
+		if !ok {
+			return newError("argument to `first` must be array, got %s", args[0].Type())
+		}
+		if len(arr.Elements) > 0 {
+			return arr.Elements[0]
+		}
+		return NULL
+	}},
+	"last": {Fn: func(args ...Object) Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments for `last`: got=%d, want=1", len(args))
+		}
+		arr, ok := args[0].(*Array)
+		if !ok {
+			return newError("argument to `last` must be array, got %s", args[0].Type())
+		}
+		if length := len(arr.Elements); length > 0 {
+			return arr.Elements[length-1]
+		}
+		return NULL
+	}},
+	"rest": {Fn: func(args ...Object) Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments for `rest`: got=%d, want=1", len(args))
+		}
+		arr, ok := args[0].(*Array)
+		if !ok {
+			return newError("argument to `rest` must be array, got %s", args[0].Type())
+		}
+		length := len(arr.Elements)
+		if length == 0 {
+			return NULL
+		}
+		newElems := make([]Object, length-1)
+		copy(newElems, arr.Elements[1:length])
+		return &Array{Elements: newElems}
+	}},
+	"push": {Fn: func(args ...Object) Object {
+		if len(args) != 2 {
+			return newError("wrong number of arguments for `push`: got=%d, want=2", len(args))
+		}
+		arr, ok := args[0].(*Array)
+		if !ok {
+			return newError("argument to `push` must be array, got %s", args[0].Type())
+		}
+		length := len(arr.Elements)
+		newElems := make([]Object, length+1)
+		copy(newElems, arr.Elements)
+		newElems[length] = args[1]
+		return &Array{Elements: newElems}
+	}},
+	"keys": {Fn: func(args ...Object) Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments for `keys`: got=%d, want=1", len(args))
+		}
+		hash, ok := args[0].(*Hash)
+		if !ok {
+			return newError("argument to `keys` must be hash, got %s", args[0].Type())
+		}
+		keys := make([]Object, 0, len(hash.Pairs))
+		for _, pair := range hash.Pairs {
+			keys = append(keys, pair.Key)
+		}
+		return &Array{Elements: keys}
+	}},
+	"values": {Fn: func(args ...Object) Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments for `values`: got=%d, want=1", len(args))
+		}
+		hash, ok := args[0].(*Hash)
+		if !ok {
+			return newError("argument to `values` must be hash, got %s", args[0].Type())
+		}
+		values := make([]Object, 0, len(hash.Pairs))
+		for _, pair := range hash.Pairs {
+			values = append(values, pair.Value)
+		}
+		return &Array{Elements: values}
+	}},
+	"print": {Fn: func(args ...Object) Object {
+		fmt.Print(joinInspected(args))
+		return NULL
+	}},
+	"println": {Fn: func(args ...Object) Object {
+		fmt.Println(joinInspected(args))
+		return NULL
+	}},
+	"str": {Fn: func(args ...Object) Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments for `str`: got=%d, want=1", len(args))
+		}
+		return &String{Value: args[0].Inspect()}
+	}},
+	"int": {Fn: func(args ...Object) Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments for `int`: got=%d, want=1", len(args))
+		}
+		switch arg := args[0].(type) {
+		case *Integer:
+			return arg
+		case *String:
+			v, err := strconv.ParseInt(strings.TrimSpace(arg.Value), 10, 64)
+			if err != nil {
+				return newError("cannot convert %q to int", arg.Value)
+			}
+			return &Integer{Value: v}
+		default:
+			return newError("argument to `int` not supported, got %s", args[0].Type())
+		}
+	}},
+	"typeof": {Fn: func(args ...Object) Object {
+		if len(args) != 1 {
+			return newError("wrong number of arguments for `typeof`: got=%d, want=1", len(args))
+		}
+		return &String{Value: string(args[0].Type())}
+	}},
+}
+
+func joinInspected(args []Object) string {
+	parts := make([]string, len(args))
+	for i, a := range args {
+		parts[i] = a.Inspect()
+	}
+	return strings.Join(parts, " ")
+}
+
+// Iterator is implemented by helpers that walk over a value one element at
+// a time. ForStatement drives any Object that can be turned into one of
+// these uniformly, regardless of whether the underlying value is a range,
+// a string, an array or a hash.
+type Iterator interface {
+	Next() Object
+	Done() bool
+}
+
+// rangeIterator counts 0..n (exclusive) over an Integer used as a for-loop
+// bound, e.g. `for i in 5`.
+type rangeIterator struct {
+	cur int64
+	end int64
+}
+
+func (it *rangeIterator) Done() bool { return it.cur >= it.end }
+func (it *rangeIterator) Next() Object {
+	v := &Integer{Value: it.cur}
+	it.cur++
+	return v
+}
+
+// stringIterator walks a string one byte at a time, matching the lexer's
+// own byte-oriented handling of strings.
+type stringIterator struct {
+	chars []byte
+	pos   int
+}
+
+func (it *stringIterator) Done() bool { return it.pos >= len(it.chars) }
+func (it *stringIterator) Next() Object {
+	v := &String{Value: string(it.chars[it.pos])}
+	it.pos++
+	return v
+}
+
+// arrayIterator walks an Array's elements in order.
+type arrayIterator struct {
+	elems []Object
+	pos   int
+}
+
+func (it *arrayIterator) Done() bool { return it.pos >= len(it.elems) }
+func (it *arrayIterator) Next() Object {
+	v := it.elems[it.pos]
+	it.pos++
+	return v
+}
+
+// hashKeyIterator walks a Hash's keys, e.g. for `for k in someMap`.
+type hashKeyIterator struct {
+	keys []Object
+	pos  int
+}
+
+func (it *hashKeyIterator) Done() bool { return it.pos >= len(it.keys) }
+func (it *hashKeyIterator) Next() Object {
+	v := it.keys[it.pos]
+	it.pos++
+	return v
+}
+
+// toIterator adapts obj into an Iterator for ForStatement. Objects that
+// already implement Iterator themselves are used as-is; everything else
+// falls back to the built-in adapters below.
+func toIterator(obj Object) (Iterator, bool) {
+	if it, ok := obj.(Iterator); ok {
+		return it, true
+	}
+	switch o := obj.(type) {
+	case *Integer:
+		return &rangeIterator{end: o.Value}, true
+	case *String:
+		return &stringIterator{chars: []byte(o.Value)}, true
+	case *Array:
+		return &arrayIterator{elems: o.Elements}, true
+	case *Hash:
+		keys := make([]Object, 0, len(o.Pairs))
+		for _, pair := range o.Pairs {
+			keys = append(keys, pair.Key)
+		}
+		return &hashKeyIterator{keys: keys}, true
+	default:
+		return nil, false
+	}
+}
+
 // Evaluation environment for variables
 type EvalEnv struct {
 	store map[string]Object
@@ -84,7 +469,13 @@ func (e *EvalEnv) Set(name string, val Object) Object {
 }
 
 // Evaluator handles expression evaluation
-type Evaluator struct{}
+type Evaluator struct {
+	// Loop is consulted when an EventHandler statement is evaluated. It is
+	// nil unless the host sets it (runFile in main.go does, the REPL does
+	// not), in which case `on` declarations are parsed but have nowhere to
+	// register and are simply ignored.
+	Loop *EventLoop
+}
 
 func NewEvaluator() *Evaluator {
 	return &Evaluator{}
@@ -96,9 +487,45 @@ func (eval *Evaluator) Eval(node Expression, env *EvalEnv) Object {
 	case *IntegerLiteral:
 		return &Integer{Value: node.Value}
 
+	case *FloatLiteral:
+		return &Float{Value: node.Value}
+
+	case *StringLiteral:
+		return &String{Value: node.Value}
+
+	case *BooleanLiteral:
+		return nativeBoolToPyMonkeyBoolean(node.Value)
+
+	case *NilLiteral:
+		return NULL
+
 	case *Identifier:
 		return eval.evalIdentifier(node, env)
 
+	case *CallExpression:
+		return eval.evalCallExpression(node, env)
+
+	case *ArrayLiteral:
+		elements := eval.evalExpressions(node.Elements, env)
+		if len(elements) == 1 && isError(elements[0]) {
+			return elements[0]
+		}
+		return &Array{Elements: elements}
+
+	case *HashLiteral:
+		return eval.evalHashLiteral(node, env)
+
+	case *IndexExpression:
+		left := eval.Eval(node.Left, env)
+		if isError(left) {
+			return left
+		}
+		index := eval.Eval(node.Index, env)
+		if isError(index) {
+			return index
+		}
+		return eval.evalIndexExpression(left, index)
+
 	case *PrefixExpression:
 		right := eval.Eval(node.Right, env)
 		if isError(right) {
@@ -107,6 +534,9 @@ func (eval *Evaluator) Eval(node Expression, env *EvalEnv) Object {
 		return eval.evalPrefixExpression(node.Operator, right)
 
 	case *InfixExpression:
+		if node.Operator == "&&" || node.Operator == "||" {
+			return eval.evalLogicalExpression(node, env)
+		}
 		left := eval.Eval(node.Left, env)
 		if isError(left) {
 			return left
@@ -125,12 +555,346 @@ func (eval *Evaluator) Eval(node Expression, env *EvalEnv) Object {
 	}
 }
 
+// EvalProgram is the entry point for evaluating a whole parsed program. A
+// top-level `return` unwraps its ReturnValue immediately, matching what a
+// script running to completion is expected to produce.
+func (eval *Evaluator) EvalProgram(program *Program, env *EvalEnv) Object {
+	var result Object = NULL
+
+	for _, stmt := range program.Statements {
+		result = eval.evalStatement(stmt, env)
+
+		switch result := result.(type) {
+		case *ReturnValue:
+			return result.Value
+		case *Error:
+			return result
+		}
+	}
+
+	return result
+}
+
+// evalStatement dispatches on the concrete Statement type. Statements that
+// can escape their immediate scope (return/break/continue) are returned
+// as-is so callers up the chain (evalBlockStatement, loop evaluators) can
+// decide whether to unwrap or propagate them further.
+func (eval *Evaluator) evalStatement(stmt Statement, env *EvalEnv) Object {
+	switch node := stmt.(type) {
+	case *ExpressionStatement:
+		return eval.Eval(node.Expression, env)
+
+	case *VariableDeclaration:
+		val := eval.Eval(node.Value, env)
+		if isError(val) {
+			return val
+		}
+		env.Set(node.Name.Value, val)
+		return val
+
+	case *BlockStatement:
+		return eval.evalBlockStatement(node, env)
+
+	case *ReturnStatement:
+		if node.ReturnValue == nil {
+			return &ReturnValue{Value: NULL}
+		}
+		val := eval.Eval(node.ReturnValue, env)
+		if isError(val) {
+			return val
+		}
+		return &ReturnValue{Value: val}
+
+	case *BreakStatement:
+		return BREAK_SIGNAL
+
+	case *ContinueStatement:
+		return CONTINUE_SIGNAL
+
+	case *IfStatement:
+		return eval.evalIfStatement(node, env)
+
+	case *WhileStatement:
+		return eval.evalWhileStatement(node, env)
+
+	case *LoopStatement:
+		return eval.evalLoopStatement(node, env)
+
+	case *ForStatement:
+		return eval.evalForStatement(node, env)
+
+	case *FunctionStatement:
+		fn := &Function{Parameters: node.Parameters, Body: node.Body, Env: env}
+		env.Set(node.Name.Value, fn)
+		return fn
+
+	case *EventHandler:
+		if eval.Loop != nil {
+			eval.Loop.Register(node, env)
+		}
+		return NULL
+
+	default:
+		return newError("unknown statement type: %T", stmt)
+	}
+}
+
+// evalBlockStatement evaluates each statement in turn, propagating
+// return/break/continue/error results up unevaluated so the enclosing
+// construct (function call, loop, or program) can act on them.
+func (eval *Evaluator) evalBlockStatement(block *BlockStatement, env *EvalEnv) Object {
+	var result Object = NULL
+
+	for _, stmt := range block.Statements {
+		result = eval.evalStatement(stmt, env)
+
+		if result != nil {
+			switch result.(type) {
+			case *ReturnValue, *BreakObject, *ContinueObject, *Error:
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
+func (eval *Evaluator) evalIfStatement(node *IfStatement, env *EvalEnv) Object {
+	cond := eval.Eval(node.Condition, env)
+	if isError(cond) {
+		return cond
+	}
+	if isTruthy(cond) {
+		return eval.evalBlockStatement(node.ThenBlock, env)
+	}
+
+	for _, elif := range node.ElseIfs {
+		cond := eval.Eval(elif.Condition, env)
+		if isError(cond) {
+			return cond
+		}
+		if isTruthy(cond) {
+			return eval.evalBlockStatement(elif.Block, env)
+		}
+	}
+
+	if node.ElseBlock != nil {
+		return eval.evalBlockStatement(node.ElseBlock, env)
+	}
+
+	return NULL
+}
+
+func (eval *Evaluator) evalWhileStatement(node *WhileStatement, env *EvalEnv) Object {
+	for {
+		cond := eval.Eval(node.Condition, env)
+		if isError(cond) {
+			return cond
+		}
+		if !isTruthy(cond) {
+			break
+		}
+
+		result := eval.evalBlockStatement(node.Block, env)
+		if isError(result) {
+			return result
+		}
+		if _, ok := result.(*ReturnValue); ok {
+			return result
+		}
+		if _, ok := result.(*BreakObject); ok {
+			break
+		}
+		// *ContinueObject and regular values just fall through to the next iteration.
+	}
+
+	return NULL
+}
+
+func (eval *Evaluator) evalLoopStatement(node *LoopStatement, env *EvalEnv) Object {
+	for {
+		result := eval.evalBlockStatement(node.Block, env)
+		if isError(result) {
+			return result
+		}
+		if _, ok := result.(*ReturnValue); ok {
+			return result
+		}
+		if _, ok := result.(*BreakObject); ok {
+			break
+		}
+	}
+
+	return NULL
+}
+
+func (eval *Evaluator) evalForStatement(node *ForStatement, env *EvalEnv) Object {
+	iterable := eval.Eval(node.Iterable, env)
+	if isError(iterable) {
+		return iterable
+	}
+
+	it, ok := toIterator(iterable)
+	if !ok {
+		return newError("object is not iterable: %s", iterable.Type())
+	}
+
+	for !it.Done() {
+		loopEnv := NewEnclosedEnv(env)
+		loopEnv.Set(node.Identifier.Value, it.Next())
+
+		result := eval.evalBlockStatement(node.Block, loopEnv)
+		if isError(result) {
+			return result
+		}
+		if _, ok := result.(*ReturnValue); ok {
+			return result
+		}
+		if _, ok := result.(*BreakObject); ok {
+			break
+		}
+	}
+
+	return NULL
+}
+
+// isTruthy reports whether obj should be treated as true in a condition.
+// Null, false and the zero Integer are falsy; everything else is truthy.
+func isTruthy(obj Object) bool {
+	switch o := obj.(type) {
+	case *Null:
+		return false
+	case *Boolean:
+		return o.Value
+	case *Integer:
+		return o.Value != 0
+	default:
+		return true
+	}
+}
+
 func (eval *Evaluator) evalIdentifier(node *Identifier, env *EvalEnv) Object {
-	val, ok := env.Get(node.Value)
+	if val, ok := env.Get(node.Value); ok {
+		return val
+	}
+	if builtin, ok := builtins[node.Value]; ok {
+		return builtin
+	}
+	return newError("identifier not found: " + node.Value)
+}
+
+func (eval *Evaluator) evalHashLiteral(node *HashLiteral, env *EvalEnv) Object {
+	pairs := make(map[HashKey]HashPair)
+
+	for keyNode, valueNode := range node.Pairs {
+		key := eval.Eval(keyNode, env)
+		if isError(key) {
+			return key
+		}
+
+		hashable, ok := key.(Hashable)
+		if !ok {
+			return newError("unusable as hash key: %s", key.Type())
+		}
+
+		value := eval.Eval(valueNode, env)
+		if isError(value) {
+			return value
+		}
+
+		pairs[hashable.HashKey()] = HashPair{Key: key, Value: value}
+	}
+
+	return &Hash{Pairs: pairs}
+}
+
+func (eval *Evaluator) evalIndexExpression(left, index Object) Object {
+	switch {
+	case left.Type() == ArrayType && index.Type() == IntType:
+		return evalArrayIndexExpression(left.(*Array), index.(*Integer))
+	case left.Type() == HashType:
+		return evalHashIndexExpression(left.(*Hash), index)
+	default:
+		return newError("index operator not supported: %s", left.Type())
+	}
+}
+
+func evalArrayIndexExpression(arr *Array, index *Integer) Object {
+	idx := index.Value
+	max := int64(len(arr.Elements) - 1)
+	if idx < 0 || idx > max {
+		return NULL
+	}
+	return arr.Elements[idx]
+}
+
+func evalHashIndexExpression(hash *Hash, index Object) Object {
+	key, ok := index.(Hashable)
 	if !ok {
-		return newError("identifier not found: " + node.Value)
+		return newError("unusable as hash key: %s", index.Type())
 	}
-	return val
+
+	pair, ok := hash.Pairs[key.HashKey()]
+	if !ok {
+		return NULL
+	}
+	return pair.Value
+}
+
+func (eval *Evaluator) evalCallExpression(node *CallExpression, env *EvalEnv) Object {
+	fn := eval.Eval(node.Function, env)
+	if isError(fn) {
+		return fn
+	}
+
+	args := eval.evalExpressions(node.Arguments, env)
+	if len(args) == 1 && isError(args[0]) {
+		return args[0]
+	}
+
+	return eval.applyFunction(fn, args)
+}
+
+func (eval *Evaluator) evalExpressions(exps []Expression, env *EvalEnv) []Object {
+	var result []Object
+	for _, e := range exps {
+		evaluated := eval.Eval(e, env)
+		if isError(evaluated) {
+			return []Object{evaluated}
+		}
+		result = append(result, evaluated)
+	}
+	return result
+}
+
+func (eval *Evaluator) applyFunction(fn Object, args []Object) Object {
+	switch fn := fn.(type) {
+	case *Function:
+		extendedEnv := extendFunctionEnv(fn, args)
+		result := eval.evalBlockStatement(fn.Body, extendedEnv)
+		return unwrapReturnValue(result)
+	case *Builtin:
+		return fn.Fn(args...)
+	default:
+		return newError("not a function: %s", fn.Type())
+	}
+}
+
+func extendFunctionEnv(fn *Function, args []Object) *EvalEnv {
+	env := NewEnclosedEnv(fn.Env)
+	for i, param := range fn.Parameters {
+		if i < len(args) {
+			env.Set(param.Name.Value, args[i])
+		}
+	}
+	return env
+}
+
+func unwrapReturnValue(obj Object) Object {
+	if rv, ok := obj.(*ReturnValue); ok {
+		return rv.Value
+	}
+	return obj
 }
 
 func (eval *Evaluator) evalPrefixExpression(operator string, right Object) Object {
@@ -139,38 +903,123 @@ func (eval *Evaluator) evalPrefixExpression(operator string, right Object) Objec
 		return eval.evalMinusPrefixOperatorExpression(right)
 	case "+":
 		return eval.evalPlusPrefixOperatorExpression(right)
+	case "!":
+		return eval.evalBangOperatorExpression(right)
 	default:
-		return newError("unknown operator: %s%T", operator, right)
+		return newError("unknown operator: %s%s", operator, right.Type())
 	}
 }
 
-func (eval *Evaluator) evalMinusPrefixOperatorExpression(right Object) Object {
-	if right.Type() != IntType {
-		return newError("unknown operator: -%T", right)
+func (eval *Evaluator) evalBangOperatorExpression(right Object) Object {
+	return nativeBoolToPyMonkeyBoolean(!isTruthy(right))
+}
+
+// evalLogicalExpression implements short-circuit && and ||: the right
+// operand is only evaluated when the left operand hasn't already decided
+// the result.
+func (eval *Evaluator) evalLogicalExpression(node *InfixExpression, env *EvalEnv) Object {
+	left := eval.Eval(node.Left, env)
+	if isError(left) {
+		return left
+	}
+
+	switch node.Operator {
+	case "&&":
+		if !isTruthy(left) {
+			return FALSE
+		}
+		right := eval.Eval(node.Right, env)
+		if isError(right) {
+			return right
+		}
+		return nativeBoolToPyMonkeyBoolean(isTruthy(right))
+	case "||":
+		if isTruthy(left) {
+			return TRUE
+		}
+		right := eval.Eval(node.Right, env)
+		if isError(right) {
+			return right
+		}
+		return nativeBoolToPyMonkeyBoolean(isTruthy(right))
+	default:
+		return newError("unknown logical operator: %s", node.Operator)
 	}
+}
 
-	value := right.(*Integer).Value
-	return &Integer{Value: -value}
+func (eval *Evaluator) evalMinusPrefixOperatorExpression(right Object) Object {
+	switch right.Type() {
+	case IntType:
+		return &Integer{Value: -right.(*Integer).Value}
+	case FloatType:
+		return &Float{Value: -right.(*Float).Value}
+	default:
+		return newError("unknown operator: -%s", right.Type())
+	}
 }
 
 func (eval *Evaluator) evalPlusPrefixOperatorExpression(right Object) Object {
-	if right.Type() != IntType {
-		return newError("unknown operator: +%T", right)
+	if right.Type() != IntType && right.Type() != FloatType {
+		return newError("unknown operator: +%s", right.Type())
 	}
 
-	return right // +x is just x for integers
+	return right // +x is just x for numbers
+}
+
+// isNumeric reports whether obj is an Integer or Float, the two types
+// evalNumericInfixExpression knows how to promote between.
+func isNumeric(obj Object) bool {
+	return obj.Type() == IntType || obj.Type() == FloatType
 }
 
 func (eval *Evaluator) evalInfixExpression(operator string, left, right Object) Object {
 	switch {
-	case left.Type() == IntType && right.Type() == IntType:
-		return eval.evalIntegerInfixExpression(operator, left, right)
+	case isNumeric(left) && isNumeric(right):
+		return eval.evalNumericInfixExpression(operator, left, right)
+	case left.Type() == StringType && right.Type() == StringType:
+		return eval.evalStringInfixExpression(operator, left, right)
+	case (left.Type() == StringType || right.Type() == StringType) && operator == "+":
+		// String concatenation coerces the other operand via Inspect(),
+		// matching the typechecker's string+anything->string overload.
+		return &String{Value: left.Inspect() + right.Inspect()}
+	case left.Type() == BoolType && right.Type() == BoolType:
+		return eval.evalBooleanInfixExpression(operator, left, right)
 	case operator == "==":
 		return nativeBoolToPyMonkeyBoolean(left == right)
 	case operator == "!=":
 		return nativeBoolToPyMonkeyBoolean(left != right)
 	default:
-		return newError("unknown operator: %T %s %T", left, operator, right)
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+func (eval *Evaluator) evalBooleanInfixExpression(operator string, left, right Object) Object {
+	leftVal := left.(*Boolean).Value
+	rightVal := right.(*Boolean).Value
+
+	switch operator {
+	case "==":
+		return nativeBoolToPyMonkeyBoolean(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToPyMonkeyBoolean(leftVal != rightVal)
+	default:
+		return newError("unknown operator: %s %s %s", BoolType, operator, BoolType)
+	}
+}
+
+func (eval *Evaluator) evalStringInfixExpression(operator string, left, right Object) Object {
+	leftVal := left.(*String).Value
+	rightVal := right.(*String).Value
+
+	switch operator {
+	case "+":
+		return &String{Value: leftVal + rightVal}
+	case "==":
+		return nativeBoolToPyMonkeyBoolean(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToPyMonkeyBoolean(leftVal != rightVal)
+	default:
+		return newError("unknown operator: %s %s %s", StringType, operator, StringType)
 	}
 }
 
@@ -190,56 +1039,100 @@ func (eval *Evaluator) evalIntegerInfixExpression(operator string, left, right O
 			return newError("division by zero")
 		}
 		return &Integer{Value: leftVal / rightVal}
+	case "%":
+		if rightVal == 0 {
+			return newError("division by zero")
+		}
+		return &Integer{Value: leftVal % rightVal}
 	case "<":
 		return nativeBoolToPyMonkeyBoolean(leftVal < rightVal)
 	case ">":
 		return nativeBoolToPyMonkeyBoolean(leftVal > rightVal)
+	case "<=":
+		return nativeBoolToPyMonkeyBoolean(leftVal <= rightVal)
+	case ">=":
+		return nativeBoolToPyMonkeyBoolean(leftVal >= rightVal)
 	case "==":
 		return nativeBoolToPyMonkeyBoolean(leftVal == rightVal)
 	case "!=":
 		return nativeBoolToPyMonkeyBoolean(leftVal != rightVal)
 	default:
-		return newError("unknown operator: %s", operator)
+		return newError("unknown operator: %s %s %s", IntType, operator, IntType)
 	}
 }
 
-func (eval *Evaluator) typeof(node *TypeOfExpression, env *EvalEnv) Object {
-	// For typeof, we want to determine the type without fully evaluating
-	// This is a simplified implementation
-	switch expr := node.Expr.(type) {
-	case *IntegerLiteral:
-		return &String{Value: string(IntType)}
-	case *Identifier:
-		// Try to get the identifier's value to determine its type
-		val, ok := env.Get(expr.Value)
-		if !ok {
-			return newError("identifier not found: " + expr.Value)
-		}
-		return &String{Value: string(val.Type())}
-	case *InfixExpression:
-		// For expressions, we need to evaluate to determine type
-		result := eval.Eval(expr, env)
-		if isError(result) {
-			return result
+// evalNumericInfixExpression handles int/float and mixed int-float infix
+// operations, promoting to float64 whenever either operand is a Float
+// (matching the typechecker's int+float->float promotion rule).
+func (eval *Evaluator) evalNumericInfixExpression(operator string, left, right Object) Object {
+	if left.Type() == IntType && right.Type() == IntType {
+		return eval.evalIntegerInfixExpression(operator, left, right)
+	}
+
+	leftVal := numericValue(left)
+	rightVal := numericValue(right)
+
+	switch operator {
+	case "+":
+		return &Float{Value: leftVal + rightVal}
+	case "-":
+		return &Float{Value: leftVal - rightVal}
+	case "*":
+		return &Float{Value: leftVal * rightVal}
+	case "/":
+		if rightVal == 0 {
+			return newError("division by zero")
 		}
-		return &String{Value: string(result.Type())}
-	case *PrefixExpression:
-		result := eval.Eval(expr, env)
-		if isError(result) {
-			return result
+		return &Float{Value: leftVal / rightVal}
+	case "%":
+		if rightVal == 0 {
+			return newError("division by zero")
 		}
-		return &String{Value: string(result.Type())}
+		return &Float{Value: math.Mod(leftVal, rightVal)}
+	case "<":
+		return nativeBoolToPyMonkeyBoolean(leftVal < rightVal)
+	case ">":
+		return nativeBoolToPyMonkeyBoolean(leftVal > rightVal)
+	case "<=":
+		return nativeBoolToPyMonkeyBoolean(leftVal <= rightVal)
+	case ">=":
+		return nativeBoolToPyMonkeyBoolean(leftVal >= rightVal)
+	case "==":
+		return nativeBoolToPyMonkeyBoolean(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToPyMonkeyBoolean(leftVal != rightVal)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+// numericValue extracts an Integer or Float's value as float64.
+func numericValue(obj Object) float64 {
+	switch o := obj.(type) {
+	case *Integer:
+		return float64(o.Value)
+	case *Float:
+		return o.Value
 	default:
-		return newError("cannot determine type of expression: %T", expr)
+		return 0
+	}
+}
+
+func (eval *Evaluator) typeof(node *TypeOfExpression, env *EvalEnv) Object {
+	result := eval.Eval(node.Expr, env)
+	if isError(result) {
+		return result
 	}
+	return &String{Value: string(result.Type())}
 }
 
 // Helper functions
 func isError(obj Object) bool {
-	if obj != nil {
-		return obj.Type() == UnknownType && obj.Inspect()[:5] == "ERROR"
+	if obj == nil {
+		return false
 	}
-	return false
+	_, ok := obj.(*Error)
+	return ok
 }
 
 func newError(format string, a ...interface{}) *Error {
@@ -248,9 +1141,9 @@ func newError(format string, a ...interface{}) *Error {
 
 func nativeBoolToPyMonkeyBoolean(input bool) Object {
 	if input {
-		return &Integer{Value: 1} // Using 1 for true
+		return TRUE
 	}
-	return &Integer{Value: 0} // Using 0 for false
+	return FALSE
 }
 
 // Helper function to convert string to object for testing
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+)
+
+// registeredHandler is what an EventLoop actually runs: a body, the
+// parameters an event's args bind to, and the environment the body
+// closes over (the top-level env for an `on` declaration, or a
+// function's own captured env for a handler registered via `every`).
+type registeredHandler struct {
+	params []*Parameter
+	body   *BlockStatement
+	env    *EvalEnv
+}
+
+type dispatchedEvent struct {
+	name string
+	args []Object
+}
+
+// EventLoop drains dispatched events and runs whichever `on` handler (or
+// `every` timer) matches the event's name, so an interpreted program can
+// be reactive instead of strictly run-to-completion. Dispatch is safe to
+// call from other goroutines (timers, input readers).
+type EventLoop struct {
+	eval     *Evaluator
+	env      *EvalEnv
+	handlers map[string]*registeredHandler
+	events   chan dispatchedEvent
+	quit     chan struct{}
+	timerSeq int
+}
+
+// NewEventLoop creates a loop that runs handler bodies through eval,
+// rooted at env (the same top-level environment the script ran in).
+func NewEventLoop(eval *Evaluator, env *EvalEnv) *EventLoop {
+	return &EventLoop{
+		eval:     eval,
+		env:      env,
+		handlers: make(map[string]*registeredHandler),
+		events:   make(chan dispatchedEvent, 64),
+		quit:     make(chan struct{}),
+	}
+}
+
+// Register records an `on` declaration so future Dispatch(h.Name, ...)
+// calls run its body.
+func (el *EventLoop) Register(h *EventHandler, env *EvalEnv) {
+	el.handlers[h.Name] = &registeredHandler{params: h.Params, body: h.Body, env: env}
+}
+
+// HasHandlers reports whether any handler was registered, which main.go
+// uses to decide whether a program should run once or enter the loop.
+func (el *EventLoop) HasHandlers() bool {
+	return len(el.handlers) > 0
+}
+
+// Dispatch enqueues an event by name for the loop to run.
+func (el *EventLoop) Dispatch(name string, args ...Object) {
+	select {
+	case el.events <- dispatchedEvent{name: name, args: args}:
+	case <-el.quit:
+	}
+}
+
+// Quit stops Run from blocking on further events. Safe to call more than
+// once; it is normally reached through the `quit` builtin.
+func (el *EventLoop) Quit() {
+	select {
+	case <-el.quit:
+		// already closed
+	default:
+		close(el.quit)
+	}
+}
+
+// Run drains dispatched events until Quit is called.
+func (el *EventLoop) Run() {
+	for {
+		select {
+		case ev := <-el.events:
+			el.runHandler(ev)
+		case <-el.quit:
+			return
+		}
+	}
+}
+
+func (el *EventLoop) runHandler(ev dispatchedEvent) {
+	handler, ok := el.handlers[ev.name]
+	if !ok {
+		return
+	}
+
+	handlerEnv := NewEnclosedEnv(handler.env)
+	for i, param := range handler.params {
+		if i < len(ev.args) {
+			handlerEnv.Set(param.Name.Value, ev.args[i])
+		}
+	}
+
+	el.eval.evalBlockStatement(handler.body, handlerEnv)
+}
+
+// InstallBuiltins wires the `every` and `quit` builtins into the loop's
+// top-level environment. It is only called once a script is known to
+// register at least one handler, so a plain script's identifier lookups
+// are unaffected.
+func (el *EventLoop) InstallBuiltins() {
+	el.env.Set("every", &Builtin{Fn: func(args ...Object) Object {
+		if len(args) != 2 {
+			return newError("wrong number of arguments for `every`: got=%d, want=2", len(args))
+		}
+		ms, ok := args[0].(*Integer)
+		if !ok {
+			return newError("argument to `every` must be int milliseconds, got %s", args[0].Type())
+		}
+		fn, ok := args[1].(*Function)
+		if !ok {
+			return newError("argument to `every` must be a function, got %s", args[1].Type())
+		}
+
+		el.timerSeq++
+		name := fmt.Sprintf("tick#%d", el.timerSeq)
+		el.handlers[name] = &registeredHandler{params: fn.Parameters, body: fn.Body, env: fn.Env}
+
+		go el.runTicker(name, time.Duration(ms.Value)*time.Millisecond)
+
+		return NULL
+	}})
+
+	el.env.Set("quit", &Builtin{Fn: func(args ...Object) Object {
+		el.Quit()
+		return NULL
+	}})
+}
+
+func (el *EventLoop) runTicker(name string, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			el.Dispatch(name)
+		case <-el.quit:
+			return
+		}
+	}
+}
+
+// StartReadlineSource reads lines from in on a background goroutine and
+// dispatches a "key" event carrying each line, so a program can react to
+// input via `on key(string line) { ... }`.
+func (el *EventLoop) StartReadlineSource(in io.Reader) {
+	go func() {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			select {
+			case <-el.quit:
+				return
+			default:
+			}
+			el.Dispatch("key", &String{Value: scanner.Text()})
+		}
+		// Input exhausted: nothing left to ever dispatch a "key" event
+		// again, so stop Run from blocking on the empty events channel
+		// forever.
+		el.Quit()
+	}()
+}
@@ -11,16 +11,24 @@ type Lexer struct {
 	position     int  // current char position
 	readPosition int  // next char position
 	ch           byte // current char
+	line         int  // current 1-based line, for error reporting
+	col          int  // current 1-based column, for error reporting
+
+	// emitComments makes NextToken return COMMENT tokens for `//` comments
+	// instead of silently skipping them. Set by the parser when it is
+	// constructed with the ParseComments mode.
+	emitComments bool
 }
 
 // NewLexer initializes Lexer with input string
 func NewLexer(input string) *Lexer {
-	l := &Lexer{input: input}
+	l := &Lexer{input: input, line: 1}
 	l.readChar()
 	return l
 }
 
-// readChar advances the lexer positions and sets current char
+// readChar advances the lexer positions and sets current char, keeping
+// line/col in sync so tokens can report where they came from.
 func (l *Lexer) readChar() {
 	if l.readPosition >= len(l.input) {
 		l.ch = 0 // EOF
@@ -29,6 +37,13 @@ func (l *Lexer) readChar() {
 	}
 	l.position = l.readPosition
 	l.readPosition++
+
+	if l.ch == '\n' {
+		l.line++
+		l.col = 0
+	} else {
+		l.col++
+	}
 }
 
 // peekChar returns the next character without advancing position
@@ -84,10 +99,33 @@ func (l *Lexer) readString() string {
 	return l.input[position:l.position]
 }
 
-// NextToken returns the next token from input
-func (l *Lexer) NextToken() Token {
-	var tok Token
-	l.skipWhitespace()
+// readLineComment consumes a `//` comment through (but not including) the
+// terminating newline or EOF, returning the consumed text.
+func (l *Lexer) readLineComment() string {
+	start := l.position
+	for l.ch != '\n' && l.ch != 0 {
+		l.readChar()
+	}
+	return l.input[start:l.position]
+}
+
+// NextToken returns the next token from input, tagged with the line/column
+// it starts on so callers (parser errors, the REPL) can point at it.
+func (l *Lexer) NextToken() (tok Token) {
+	for {
+		l.skipWhitespace()
+		if l.ch == '/' && l.peekChar() == '/' && !l.emitComments {
+			l.readLineComment()
+			continue
+		}
+		break
+	}
+
+	line, col := l.line, l.col
+	defer func() {
+		tok.Line = line
+		tok.Col = col
+	}()
 
 	switch l.ch {
 	case '(':
@@ -98,6 +136,12 @@ func (l *Lexer) NextToken() Token {
 		tok = Token{Type: LBRACE, Literal: string(l.ch)}
 	case '}':
 		tok = Token{Type: RBRACE, Literal: string(l.ch)}
+	case '[':
+		tok = Token{Type: LBRACKET, Literal: string(l.ch)}
+	case ']':
+		tok = Token{Type: RBRACKET, Literal: string(l.ch)}
+	case ':':
+		tok = Token{Type: COLON, Literal: string(l.ch)}
 	case ',':
 		tok = Token{Type: COMMA, Literal: string(l.ch)}
 	case ';':
@@ -109,11 +153,29 @@ func (l *Lexer) NextToken() Token {
 	case '+':
 		tok = Token{Type: ADD, Literal: string(l.ch)}
 	case '/':
+		if l.peekChar() == '/' {
+			// Only reachable with emitComments set; the non-emitting case
+			// is already skipped by the loop above NextToken's switch.
+			tok = Token{Type: COMMENT, Literal: l.readLineComment()}
+			return tok
+		}
 		tok = Token{Type: DIV, Literal: string(l.ch)}
 	case '<':
-		tok = Token{Type: LT, Literal: string(l.ch)}
+		if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: LE, Literal: string(ch) + string(l.ch)}
+		} else {
+			tok = Token{Type: LT, Literal: string(l.ch)}
+		}
 	case '>':
-		tok = Token{Type: GT, Literal: string(l.ch)}
+		if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: GE, Literal: string(ch) + string(l.ch)}
+		} else {
+			tok = Token{Type: GT, Literal: string(l.ch)}
+		}
 	case '=':
 		if l.peekChar() == '=' {
 			ch := l.ch
@@ -189,7 +251,7 @@ func lookupIdent(ident string) TokenType {
 		return IF
 	case "else":
 		return ELSE
-	case "int", "string", "void", "float":
+	case "int", "string", "void", "float", "bool":
 		return TYPE
 	case "return":
 		return RETURN
@@ -213,6 +275,8 @@ func lookupIdent(ident string) TokenType {
 		return BREAK
 	case "continue":
 		return CONTINUE
+	case "on":
+		return ON
 	default:
 		return IDENT
 	}
@@ -1,112 +1,58 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+)
 
 func main() {
-	// Test cases
-	testCases :=  []string{
-	`int fn main(int x, string y) { return 123 + 4 * 5; }`,
-	`int func main(int x, string y){ return 123 + 4 * 5; }`,
-	`int main(int x, string y) { return 123 + 4 * 5; }`,
-	`void test() { return; }`,
-	`float calculate(int a, float b, string name) { return a + b * -3; }`,
-	`int test() { return typeof(42); }`,
-	`void demo() { return typeof(x + y * 2); }`,
-	`void testIf() { if (x > 10) { return x; } }`,
-	`void testIfElse() { if (x > 10) { return x; } else { return 0; } }`,
-	`void testElseIf() { if (x > 10) { return x; } else if (x > 5) { return x + 1; } else { return 0; } }`,
-	`void testNoBraces() { if (x > 10) return x; else return 0; }`,
-	`void testDeclaration() { int x = 10; return x; }`,
-	`void testLoop() { loop { return 1; } }`,
-	`void testWhile() { while (x < 10) { return x; } }`,
-	`void testFor() { for (item in items) { return item; } }`,
-
-	// Added string & float focused tests
-	`string greet(string name) { return "Hello, " + name; }`,
-	`float addFloats(float a, float b) { return a + b; }`,
-	`string emptyString() { return ""; }`,
-	`float negativeFloat() { return -3.14; }`,
-	`void returnStringVoid() { return "not really void"; }`, // Should error or handle accordingly
-}
-
-	for i, source := range testCases {
-		fmt.Printf("=== Test Case %d ===\n", i+1)
-		fmt.Printf("Source: %s\n", source)
-
-		lexer := NewLexer(source)
-		parser := NewParser(lexer)
-
-		program := parser.ParseProgram()
-
-		if len(parser.errors) != 0 {
-			fmt.Println("Parser errors:")
-			for _, err := range parser.errors {
-				fmt.Println(" -", err)
-			}
-		} else {
-			fmt.Println("Parsed program:")
-			fmt.Println(program.String())
-		}
-		fmt.Println()
+	if len(os.Args) > 1 {
+		runFile(os.Args[1])
+		return
 	}
 
-	// Demonstrate lexer functionality
-	fmt.Println("=== Lexer Demo ===")
-	demoLexer()
-
-	// Demonstrate typeof evaluation
-	fmt.Println("\n=== Typeof Evaluation Demo ===")
-	demoTypeofEvaluation()
+	fmt.Println("Magolor REPL")
+	fmt.Println("Commands: :load <file>, :type <expr>, :history, :reset, :exit")
+	NewREPL().Start(os.Stdin, os.Stdout)
 }
 
-func demoLexer() {
-	input := "int main(int x) { return x + 42; }"
-	lexer := NewLexer(input)
-
-	fmt.Printf("Tokenizing: %s\n", input)
-	fmt.Println("Tokens:")
-
-	for {
-		tok := lexer.NextToken()
-		fmt.Printf("  Type: %-10s Literal: %s\n", tok.Type, tok.Literal)
-		if tok.Type == EOF {
-			break
-		}
+// runFile evaluates a single script file and exits non-zero on parse
+// errors, rather than dropping into the interactive loop.
+func runFile(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error reading file:", err)
+		os.Exit(1)
 	}
-}
 
-func demoTypeofEvaluation() {
-	expressions := []string{
-		"typeof(42)",
-		"typeof(x + 5)",
-		"typeof(-10)",
-	}
-
-	evaluator := NewEvaluator()
+	eval := NewEvaluator()
 	env := NewEvalEnv()
+	loop := NewEventLoop(eval, env)
+	eval.Loop = loop
 
-	// Set up some variables in the environment
-	env.Set("x", &Integer{Value: 100})
-	env.Set("name", &String{Value: "hello"})
-
-	for _, exprStr := range expressions {
-		fmt.Printf("Evaluating: %s\n", exprStr)
+	program, errs := ParseProgram(string(data))
+	if len(errs) != 0 {
+		printParseErrors(os.Stderr, errs)
+		os.Exit(1)
+	}
 
-		lexer := NewLexer(exprStr)
-		parser := NewParser(lexer)
+	if typeErrs := Check(program); len(typeErrs) != 0 {
+		for _, te := range typeErrs {
+			fmt.Fprintln(os.Stderr, te.String())
+		}
+		os.Exit(1)
+	}
 
-		// Parse as expression
-		expr := parser.parseExpression(LOWEST)
-		if len(parser.errors) != 0 {
-			fmt.Println("  Parse errors:")
-			for _, err := range parser.errors {
-				fmt.Println("   -", err)
-			}
-			continue
+	result := eval.EvalProgram(program, env)
+	if result != nil {
+		if _, ok := result.(*Null); !ok {
+			fmt.Println(result.Inspect())
 		}
+	}
 
-		result := evaluator.Eval(expr, env)
-		fmt.Printf("  Result: %s (type: %s)\n", result.Inspect(), result.Type())
-		fmt.Println()
+	if loop.HasHandlers() {
+		loop.InstallBuiltins()
+		loop.StartReadlineSource(os.Stdin)
+		loop.Run()
 	}
 }
@@ -2,27 +2,147 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strconv"
+	"strings"
 )
 
+// Mode is a bitset of optional Parser behaviors, in the spirit of
+// go/parser's Mode flags.
+const (
+	// Trace makes the parser print indented enter/exit lines for each
+	// traced parseX call, for debugging the recursive descent.
+	Trace uint = 1 << iota
+	// ParseComments makes the lexer emit COMMENT tokens instead of
+	// silently skipping them, and has the parser attach leading comments
+	// to the declaration that follows via its Doc field.
+	ParseComments
+)
+
+// prefixParseFn parses an expression that starts with the current token
+// (a literal, identifier, grouping, or prefix operator).
+type prefixParseFn func() Expression
+
+// infixParseFn parses the rest of an expression given the already-parsed
+// left-hand side (a binary operator, call, or index).
+type infixParseFn func(Expression) Expression
+
+// ParseError is a parse failure tagged with the source position of the
+// token being parsed when it happened.
+type ParseError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (pe *ParseError) String() string {
+	return fmt.Sprintf("parse error at %d:%d: %s", pe.Line, pe.Column, pe.Message)
+}
+
 // Parser struct to hold lexer and tokens for parsing
 type Parser struct {
 	lexer     *Lexer
 	curToken  Token
 	peekToken Token
 	peekPeek  Token
-	errors    []string
+	errors    []*ParseError
+
+	Mode     uint
+	indent   int       // trace indentation depth
+	traceOut io.Writer // where Trace output goes, default os.Stderr
+
+	leadComment *CommentGroup // comments collected since the last declaration, under ParseComments
+
+	prefixParseFns map[TokenType]prefixParseFn
+	infixParseFns  map[TokenType]infixParseFn
 }
 
-// NewParser creates a parser and reads tokens to initialize
+// NewParser creates a parser with the default mode (no tracing, comments
+// skipped) and reads tokens to initialize.
 func NewParser(l *Lexer) *Parser {
-	p := &Parser{lexer: l}
+	return NewParserWithMode(l, 0)
+}
+
+// NewParserWithMode creates a parser, registers every prefix/infix parse
+// function, and reads tokens to initialize, honoring mode (see Trace and
+// ParseComments).
+func NewParserWithMode(l *Lexer, mode uint) *Parser {
+	p := &Parser{lexer: l, Mode: mode, traceOut: os.Stderr}
+	if mode&ParseComments != 0 {
+		l.emitComments = true
+	}
+
+	p.prefixParseFns = make(map[TokenType]prefixParseFn)
+	p.registerPrefix(VOID, p.parseVoidLiteral)
+	p.registerPrefix(TYPE, p.parseIdentifier)
+	p.registerPrefix(IDENT, p.parseIdentifier)
+	p.registerPrefix(INT, p.parseIntegerLiteral)
+	p.registerPrefix(FLOAT, p.parseFloatLiteralExpr)
+	p.registerPrefix(STRING, p.parseStringLiteralExpr)
+	p.registerPrefix(BOOL, p.parseBooleanLiteral)
+	p.registerPrefix(NIL, p.parseNilLiteral)
+	p.registerPrefix(LPAREN, p.parseGroupedExpression)
+	p.registerPrefix(LBRACKET, p.parseArrayLiteral)
+	p.registerPrefix(LBRACE, p.parseHashLiteral)
+	p.registerPrefix(SUB, p.parsePrefixOperatorExpression)
+	p.registerPrefix(ADD, p.parsePrefixOperatorExpression)
+	p.registerPrefix(NOT, p.parsePrefixOperatorExpression)
+	p.registerPrefix(TYPEOF, p.parseTypeofExpression)
+
+	p.infixParseFns = make(map[TokenType]infixParseFn)
+	p.registerInfix(ADD, p.parseInfixExpression)
+	p.registerInfix(SUB, p.parseInfixExpression)
+	p.registerInfix(MUL, p.parseInfixExpression)
+	p.registerInfix(DIV, p.parseInfixExpression)
+	p.registerInfix(MOD, p.parseInfixExpression)
+	p.registerInfix(LT, p.parseInfixExpression)
+	p.registerInfix(GT, p.parseInfixExpression)
+	p.registerInfix(LE, p.parseInfixExpression)
+	p.registerInfix(GE, p.parseInfixExpression)
+	p.registerInfix(EQ, p.parseInfixExpression)
+	p.registerInfix(NOT_EQ, p.parseInfixExpression)
+	p.registerInfix(AND, p.parseInfixExpression)
+	p.registerInfix(OR, p.parseInfixExpression)
+	p.registerInfix(LPAREN, p.parseCallExpression)
+	p.registerInfix(LBRACKET, p.parseIndexExpression)
+
 	p.nextToken()
 	p.nextToken()
 	p.nextToken() // Load peekPeek as well
 	return p
 }
 
+func (p *Parser) registerPrefix(tokenType TokenType, fn prefixParseFn) {
+	p.prefixParseFns[tokenType] = fn
+}
+
+func (p *Parser) registerInfix(tokenType TokenType, fn infixParseFn) {
+	p.infixParseFns[tokenType] = fn
+}
+
+const traceIndent = ". "
+
+// trace prints "msg (" under the Trace mode and bumps the indent level,
+// returning p so callers can write `defer untrace(trace(p, "X"))`.
+func trace(p *Parser, msg string) *Parser {
+	if p.Mode&Trace == 0 {
+		return p
+	}
+	fmt.Fprintf(p.traceOut, "%s%s (\n", strings.Repeat(traceIndent, p.indent), msg)
+	p.indent++
+	return p
+}
+
+// untrace closes out the enter line printed by trace.
+func untrace(p *Parser) {
+	if p.Mode&Trace == 0 {
+		return
+	}
+	p.indent--
+	fmt.Fprintf(p.traceOut, "%s)\n", strings.Repeat(traceIndent, p.indent))
+}
+
 func (p *Parser) peekTokenIs(t TokenType) bool {
 	return p.peekToken.Type == t
 }
@@ -34,9 +154,13 @@ func (p *Parser) nextToken() {
 	p.peekPeek = p.lexer.NextToken()
 }
 
-// addError adds an error message to the parser's error list
+// addError records a parse error positioned at the current token.
 func (p *Parser) addError(msg string) {
-	p.errors = append(p.errors, msg)
+	p.errors = append(p.errors, &ParseError{
+		Line:    p.curToken.Line,
+		Column:  p.curToken.Col,
+		Message: msg,
+	})
 }
 
 // expectPeek checks if the next token matches expected type and advances if so
@@ -79,14 +203,28 @@ func (p *Parser) skipWhitespaceTokens() {
 // ============================================================================
 
 func (p *Parser) parseExpression(precedence int) Expression {
-	leftExp := p.parsePrefixExpression()
+	defer untrace(trace(p, "Expression"))
+	p.skipWhitespaceTokens()
+
+	prefix := p.prefixParseFns[p.curToken.Type]
+	if prefix == nil {
+		if p.curToken.Type != EOF {
+			p.addError(fmt.Sprintf("no prefix parse function for %s found", p.curToken.Type))
+		}
+		return nil
+	}
+	leftExp := prefix()
 	if leftExp == nil {
 		return nil
 	}
 
 	for p.peekToken.Type != SEMICOLON && precedence < p.peekPrecedence() && p.peekToken.Type != EOF {
+		infix := p.infixParseFns[p.peekToken.Type]
+		if infix == nil {
+			return leftExp
+		}
 		p.nextToken()
-		leftExp = p.parseInfixExpression(leftExp)
+		leftExp = infix(leftExp)
 		if leftExp == nil {
 			return nil
 		}
@@ -95,55 +233,35 @@ func (p *Parser) parseExpression(precedence int) Expression {
 	return leftExp
 }
 
-func (p *Parser) parsePrefixExpression() Expression {
-	// Skip any whitespace tokens first
-	p.skipWhitespaceTokens()
+func (p *Parser) parseVoidLiteral() Expression {
+	return &VoidLiteral{Token: p.curToken}
+}
 
-	switch p.curToken.Type {
-	case VOID:
-		return &VoidLiteral{Token: p.curToken}
-	case IDENT:
-		return &Identifier{Token: p.curToken, Value: p.curToken.Literal}
-	case INT:
-		value, err := strconv.ParseInt(p.curToken.Literal, 10, 64)
-		if err != nil {
-			p.addError(fmt.Sprintf("could not parse %q as integer", p.curToken.Literal))
-			return nil
-		}
-		return &IntegerLiteral{Token: p.curToken, Value: value}
-
-	case BOOL:
-		return p.parseBooleanLiteral()
-	case NIL:
-		return &NilLiteral{Token: p.curToken}
-	case STRING:
-    return &StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
-	case FLOAT:
-		value, err := strconv.ParseFloat(p.curToken.Literal, 64)
-		if err != nil {
-			p.addError(fmt.Sprintf("could not parse %q as float", p.curToken.Literal))
-			return nil
-		}
-		lit := &FloatLiteral{Token: p.curToken, Value: value}
-		p.nextToken()
-		return lit
-	case LPAREN:
-		p.nextToken()
-		exp := p.parseExpression(LOWEST)
-		if !p.expectPeek(RPAREN) {
-			return nil
-		}
-		return exp
-	case SUB, ADD: // Handle prefix operators like -5 or +5
-		return p.parsePrefixOperatorExpression()
-	case TYPEOF: // Handle typeof expressions
-		return p.parseTypeofExpression()
-	default:
-		if p.curToken.Type != EOF {
-			p.addError(fmt.Sprintf("no prefix parse function for %s found", p.curToken.Type))
-		}
+func (p *Parser) parseIdentifier() Expression {
+	return &Identifier{Token: p.curToken, Value: p.curToken.Literal}
+}
+
+func (p *Parser) parseIntegerLiteral() Expression {
+	value, err := strconv.ParseInt(p.curToken.Literal, 10, 64)
+	if err != nil {
+		p.addError(fmt.Sprintf("could not parse %q as integer", p.curToken.Literal))
 		return nil
 	}
+	return &IntegerLiteral{Token: p.curToken, Value: value}
+}
+
+func (p *Parser) parseNilLiteral() Expression {
+	return &NilLiteral{Token: p.curToken}
+}
+
+func (p *Parser) parseGroupedExpression() Expression {
+	defer untrace(trace(p, "GroupedExpression"))
+	p.nextToken()
+	exp := p.parseExpression(LOWEST)
+	if !p.expectPeek(RPAREN) {
+		return nil
+	}
+	return exp
 }
 
 func (p *Parser) parseBooleanLiteral() Expression {
@@ -154,6 +272,7 @@ func (p *Parser) parseBooleanLiteral() Expression {
 }
 
 func (p *Parser) parsePrefixOperatorExpression() Expression {
+	defer untrace(trace(p, "PrefixOperatorExpression"))
 	expression := &PrefixExpression{
 		Token:    p.curToken,
 		Operator: p.curToken.Literal,
@@ -165,25 +284,22 @@ func (p *Parser) parsePrefixOperatorExpression() Expression {
 	return expression
 }
 
-func (p *Parser) parseFloatLiteral() Expression {
-	lit := &FloatLiteral{Token: p.curToken}
+func (p *Parser) parseFloatLiteralExpr() Expression {
 	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
 	if err != nil {
-		p.errors = append(p.errors, "could not parse float")
+		p.addError(fmt.Sprintf("could not parse %q as float", p.curToken.Literal))
 		return nil
 	}
-	lit.Value = value
-	p.nextToken()
+	lit := &FloatLiteral{Token: p.curToken, Value: value}
 	return lit
 }
 
-func (p *Parser) parseStringLiteral() Expression {
-	lit := &StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
-	p.nextToken()
-	return lit
+func (p *Parser) parseStringLiteralExpr() Expression {
+	return &StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
 }
 
 func (p *Parser) parseInfixExpression(left Expression) Expression {
+	defer untrace(trace(p, "InfixExpression"))
 	expression := &InfixExpression{
 		Token:    p.curToken,
 		Operator: p.curToken.Literal,
@@ -197,7 +313,97 @@ func (p *Parser) parseInfixExpression(left Expression) Expression {
 	return expression
 }
 
+// parseCallExpression parses the argument list following an expression
+// that is being invoked, e.g. the `(1, 2)` in `add(1, 2)`.
+func (p *Parser) parseCallExpression(fn Expression) Expression {
+	defer untrace(trace(p, "CallExpression"))
+	exp := &CallExpression{Token: p.curToken, Function: fn}
+	exp.Arguments = p.parseExpressionList(RPAREN)
+	return exp
+}
+
+// parseArrayLiteral parses array literals like [1, 2, 3].
+func (p *Parser) parseArrayLiteral() Expression {
+	defer untrace(trace(p, "ArrayLiteral"))
+	arr := &ArrayLiteral{Token: p.curToken}
+	arr.Elements = p.parseExpressionList(RBRACKET)
+	return arr
+}
+
+// parseExpressionList parses a comma-separated list of expressions up to
+// and including the closing `end` token, shared by call arguments and
+// array elements. p.curToken must be the opening token (`(` or `[`).
+func (p *Parser) parseExpressionList(end TokenType) []Expression {
+	var list []Expression
+
+	if p.peekToken.Type == end {
+		p.nextToken()
+		return list
+	}
+
+	p.nextToken()
+	list = append(list, p.parseExpression(LOWEST))
+
+	for p.peekToken.Type == COMMA {
+		p.nextToken()
+		p.nextToken()
+		list = append(list, p.parseExpression(LOWEST))
+	}
+
+	if !p.expectPeek(end) {
+		return nil
+	}
+
+	return list
+}
+
+// parseHashLiteral parses hash literals like {"a": 1, "b": 2}.
+func (p *Parser) parseHashLiteral() Expression {
+	defer untrace(trace(p, "HashLiteral"))
+	hash := &HashLiteral{Token: p.curToken, Pairs: make(map[Expression]Expression)}
+
+	for p.peekToken.Type != RBRACE {
+		p.nextToken()
+		key := p.parseExpression(LOWEST)
+
+		if !p.expectPeek(COLON) {
+			return nil
+		}
+
+		p.nextToken()
+		value := p.parseExpression(LOWEST)
+
+		hash.Pairs[key] = value
+
+		if p.peekToken.Type != RBRACE && !p.expectPeek(COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(RBRACE) {
+		return nil
+	}
+
+	return hash
+}
+
+// parseIndexExpression parses the `[index]` suffix of arr[0] / hash["key"].
+func (p *Parser) parseIndexExpression(left Expression) Expression {
+	defer untrace(trace(p, "IndexExpression"))
+	exp := &IndexExpression{Token: p.curToken, Left: left}
+
+	p.nextToken()
+	exp.Index = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(RBRACKET) {
+		return nil
+	}
+
+	return exp
+}
+
 func (p *Parser) parseReturnStatement() *ReturnStatement {
+	defer untrace(trace(p, "ReturnStatement"))
 	stmt := &ReturnStatement{Token: p.curToken}
 
 	// Look ahead to see what comes next
@@ -248,13 +454,20 @@ func (p *Parser) curTokenIs(t TokenType) bool {
 }
 
 func (p *Parser) parseBlockStatement() *BlockStatement {
+	defer untrace(trace(p, "BlockStatement"))
 	block := &BlockStatement{Token: p.curToken}
 	p.nextToken() // consume '{'
 
 	block.Statements = []Statement{}
 
 	for !p.curTokenIs(RBRACE) && !p.curTokenIs(EOF) {
+		p.consumeLeadComments()
+		if p.curTokenIs(RBRACE) || p.curTokenIs(EOF) {
+			break
+		}
+
 		stmt := p.parseStatement()
+		p.attachDoc(stmt)
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
@@ -266,6 +479,7 @@ func (p *Parser) parseBlockStatement() *BlockStatement {
 
 // Fixed parseStatement function
 func (p *Parser) parseStatement() Statement {
+	defer untrace(trace(p, "Statement"))
 	p.skipWhitespaceTokens()
 
 	switch p.curToken.Type {
@@ -285,17 +499,31 @@ func (p *Parser) parseStatement() Statement {
 		return p.parseForStatement()
 	case FUNC:
 		return p.parseFunctionStatement()
+	case ON:
+		return p.parseEventHandler()
 	case TYPE, VOID:
-		// Look ahead to determine if this is a function or variable declaration
+		// Consume the type, including a trailing "[]" (e.g. "int[]"), then
+		// look ahead to determine if this is a function or a declaration.
+		typeTok := p.parseTypeToken()
 		if p.peekToken.Type == IDENT {
 			if p.peekPeek.Type == LPAREN {
-				return p.parseFunctionStatement()
-			} else if p.peekPeek.Type == ASSIGN {
-				return p.parseDeclarationStatement()
+				return p.parseTypedFunctionStatement(typeTok)
 			}
 			// fallback for declaration without assignment
-			return p.parseDeclarationStatement()
+			return p.parseDeclarationStatement(typeTok)
 		}
+		// Not a declaration or function header, e.g. a call to a
+		// type-named builtin like int("42"); fall back to ordinary
+		// expression parsing with the type token acting as an identifier.
+		expr := p.parseExpression(LOWEST)
+		if expr != nil {
+			stmt := &ExpressionStatement{Token: typeTok, Expression: expr}
+			if p.peekToken.Type == SEMICOLON {
+				p.nextToken()
+			}
+			return stmt
+		}
+		return nil
 	case RBRACE, SEMICOLON, EOF:
 		return nil
 	default:
@@ -314,17 +542,13 @@ func (p *Parser) parseStatement() Statement {
 		}
 		return nil
 	}
-	return nil
 }
 
-func (p *Parser) parseDeclarationStatement() *VariableDeclaration {
-	if p.curToken.Type != TYPE && p.curToken.Type != VOID {
-		p.addError(fmt.Sprintf("expected type, got %s", p.curToken.Type))
-		return nil
-	}
-
-	token := p.curToken
-
+// parseDeclarationStatement parses a variable declaration given its
+// already-consumed type token (plain, e.g. "int", or array-parameterized,
+// e.g. "int[]").
+func (p *Parser) parseDeclarationStatement(token Token) *VariableDeclaration {
+	defer untrace(trace(p, "VariableDeclaration"))
 	if !p.expectPeek(IDENT) {
 		return nil
 	}
@@ -350,6 +574,7 @@ func (p *Parser) parseDeclarationStatement() *VariableDeclaration {
 }
 
 func (p *Parser) parseWhileStatement() *WhileStatement {
+	defer untrace(trace(p, "WhileStatement"))
 	stmt := &WhileStatement{Token: p.curToken}
 
 	if !p.expectPeek(LPAREN) {
@@ -378,6 +603,7 @@ func (p *Parser) parseWhileStatement() *WhileStatement {
 }
 
 func (p *Parser) parseLoopStatement() *LoopStatement {
+	defer untrace(trace(p, "LoopStatement"))
 	stmt := &LoopStatement{Token: p.curToken}
 
 	if !p.expectPeek(LBRACE) {
@@ -390,6 +616,7 @@ func (p *Parser) parseLoopStatement() *LoopStatement {
 }
 
 func (p *Parser) parseForStatement() *ForStatement {
+	defer untrace(trace(p, "ForStatement"))
 	stmt := &ForStatement{Token: p.curToken}
 
 	if !p.expectPeek(LPAREN) {
@@ -428,30 +655,38 @@ func (p *Parser) parseForStatement() *ForStatement {
 	return stmt
 }
 
+// parseFunctionStatement parses the "func name() { ... }" form, which
+// has no explicit return type and defaults to void.
 func (p *Parser) parseFunctionStatement() *FunctionStatement {
-	stmt := &FunctionStatement{}
+	defer untrace(trace(p, "FunctionStatement"))
+	stmt := &FunctionStatement{
+		Token:      p.curToken,
+		ReturnType: Token{Type: VOID, Literal: "void"},
+	}
 
-	// Handle both "func name()" and "type name()" syntax
-	if p.curToken.Type == FUNC {
-		stmt.Token = p.curToken
-		// For "func" keyword, we assume void return type unless specified differently
-		stmt.ReturnType = Token{Type: VOID, Literal: "void"}
+	if !p.expectPeek(IDENT) {
+		return nil
+	}
+	stmt.Name = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
 
-		if !p.expectPeek(IDENT) {
-			return nil
-		}
-		stmt.Name = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
-	} else {
-		// Handle "type name()" syntax
-		stmt.ReturnType = p.curToken // Can be TYPE or VOID
-		stmt.Token = p.curToken
+	return p.finishFunctionStatement(stmt)
+}
 
-		if !p.expectPeek(IDENT) {
-			return nil
-		}
-		stmt.Name = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
+// parseTypedFunctionStatement parses the "type name() { ... }" form given
+// its already-consumed return type token (plain or array-parameterized).
+func (p *Parser) parseTypedFunctionStatement(returnType Token) *FunctionStatement {
+	defer untrace(trace(p, "TypedFunctionStatement"))
+	stmt := &FunctionStatement{Token: returnType, ReturnType: returnType}
+
+	if !p.expectPeek(IDENT) {
+		return nil
 	}
+	stmt.Name = &Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	return p.finishFunctionStatement(stmt)
+}
 
+func (p *Parser) finishFunctionStatement(stmt *FunctionStatement) *FunctionStatement {
 	// Expect opening parenthesis
 	if !p.expectPeek(LPAREN) {
 		return nil
@@ -473,6 +708,31 @@ func (p *Parser) parseFunctionStatement() *FunctionStatement {
 	return stmt
 }
 
+// parseEventHandler parses a top-level `on <name>(params) { ... }`
+// declaration, sharing the same typed-parameter and block parsing as
+// regular function declarations.
+func (p *Parser) parseEventHandler() *EventHandler {
+	defer untrace(trace(p, "EventHandler"))
+	stmt := &EventHandler{Token: p.curToken}
+
+	if !p.expectPeek(IDENT) {
+		return nil
+	}
+	stmt.Name = p.curToken.Literal
+
+	if !p.expectPeek(LPAREN) {
+		return nil
+	}
+	stmt.Params = p.parseFunctionParameters()
+
+	if !p.expectPeek(LBRACE) {
+		return nil
+	}
+	stmt.Body = p.parseBlockStatement()
+
+	return stmt
+}
+
 func (p *Parser) parseFunctionParameters() []*Parameter {
 	var params []*Parameter
 
@@ -507,13 +767,13 @@ func (p *Parser) parseFunctionParameters() []*Parameter {
 }
 
 func (p *Parser) parseParameter() *Parameter {
-	// Expect: TYPE IDENT (e.g., "int x")
+	// Expect: TYPE IDENT (e.g., "int x" or "int[] xs")
 	if p.curToken.Type != TYPE {
 		p.addError(fmt.Sprintf("expected parameter type, got %s", p.curToken.Type))
 		return nil
 	}
 
-	param := &Parameter{Type: p.curToken}
+	param := &Parameter{Type: p.parseTypeToken()}
 
 	if !p.expectPeek(IDENT) {
 		return nil
@@ -523,7 +783,21 @@ func (p *Parser) parseParameter() *Parameter {
 	return param
 }
 
+// parseTypeToken consumes p.curToken (a TYPE or VOID token) plus any
+// trailing `[]` pairs, e.g. "int[]", and returns a token carrying the
+// combined literal so callers can keep treating it as a single type token.
+func (p *Parser) parseTypeToken() Token {
+	tok := p.curToken
+	for p.peekToken.Type == LBRACKET && p.peekPeek.Type == RBRACKET {
+		p.nextToken() // consume '['
+		p.nextToken() // consume ']'
+		tok.Literal += "[]"
+	}
+	return tok
+}
+
 func (p *Parser) parseTypeofExpression() Expression {
+	defer untrace(trace(p, "TypeofExpression"))
 	expression := &TypeOfExpression{Token: p.curToken}
 
 	// Expect opening parenthesis
@@ -544,6 +818,7 @@ func (p *Parser) parseTypeofExpression() Expression {
 }
 
 func (p *Parser) parseIfStatement() *IfStatement {
+	defer untrace(trace(p, "IfStatement"))
 	stmt := &IfStatement{Token: p.curToken}
 
 	// Parse condition
@@ -657,11 +932,13 @@ func (p *Parser) ParseProgram() *Program {
 
 	for p.curToken.Type != EOF {
 		p.skipWhitespaceTokens()
+		p.consumeLeadComments()
 		if p.curToken.Type == EOF {
 			break
 		}
 
 		stmt := p.parseStatement()
+		p.attachDoc(stmt)
 		if stmt != nil {
 			program.Statements = append(program.Statements, stmt)
 		}
@@ -673,6 +950,43 @@ func (p *Parser) ParseProgram() *Program {
 	return program
 }
 
+// consumeLeadComments gathers any run of COMMENT tokens at curToken into
+// p.leadComment so the next declaration parsed can claim them as its Doc.
+// A no-op unless the parser was built with ParseComments, since otherwise
+// the lexer never produces COMMENT tokens in the first place.
+func (p *Parser) consumeLeadComments() {
+	if p.Mode&ParseComments == 0 {
+		return
+	}
+
+	var group *CommentGroup
+	for p.curToken.Type == COMMENT {
+		if group == nil {
+			group = &CommentGroup{}
+		}
+		group.List = append(group.List, &Comment{Token: p.curToken, Text: p.curToken.Literal})
+		p.nextToken()
+	}
+	if group != nil {
+		p.leadComment = group
+	}
+}
+
+// attachDoc hands any pending lead comment to stmt's Doc field, if it has
+// one, then clears it so it isn't reused by the next statement.
+func (p *Parser) attachDoc(stmt Statement) {
+	if p.leadComment == nil {
+		return
+	}
+	switch s := stmt.(type) {
+	case *FunctionStatement:
+		s.Doc = p.leadComment
+	case *VariableDeclaration:
+		s.Doc = p.leadComment
+	}
+	p.leadComment = nil
+}
+
 // ExpressionStatement represents expressions used as statements
 type ExpressionStatement struct {
 	Token      Token
@@ -752,7 +1066,25 @@ func (cs *ContinueStatement) String() string {
 	return "continue;"
 }
 
-// GetErrors returns all parsing errors
-func (p *Parser) GetErrors() []string {
+// Errors returns all parse errors, positioned and ready to format.
+func (p *Parser) Errors() []*ParseError {
 	return p.errors
 }
+
+// GetErrors returns parse errors as plain strings, kept for callers
+// written before ParseError existed.
+func (p *Parser) GetErrors() []string {
+	msgs := make([]string, len(p.errors))
+	for i, e := range p.errors {
+		msgs[i] = e.String()
+	}
+	return msgs
+}
+
+// ParseProgram parses src in one call, so callers don't need to construct
+// a Lexer/Parser pair themselves just to get a program and its errors.
+func ParseProgram(src string) (*Program, []*ParseError) {
+	p := NewParser(NewLexer(src))
+	program := p.ParseProgram()
+	return program, p.Errors()
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	replPrompt       = ">> "
+	replContinuation = ".. "
+)
+
+// REPL is a persistent read-eval-print loop. Unlike running a script once,
+// it keeps a single *EvalEnv alive across prompts so a variable or
+// function defined on one line is still visible on the next.
+type REPL struct {
+	env       *EvalEnv
+	evaluator *Evaluator
+	histPath  string
+	history   []string
+}
+
+// NewREPL creates a REPL with a fresh environment and loads persisted
+// history from ~/.mgl_history, if any, so it's available via :history.
+func NewREPL() *REPL {
+	r := &REPL{
+		env:       NewEvalEnv(),
+		evaluator: NewEvaluator(),
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		r.histPath = filepath.Join(home, ".mgl_history")
+	}
+	if r.histPath != "" {
+		if data, err := os.ReadFile(r.histPath); err == nil {
+			for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+				if line != "" {
+					r.history = append(r.history, line)
+				}
+			}
+		}
+	}
+	return r
+}
+
+// Start runs the loop against in/out until in is exhausted or :exit is
+// entered. Input that looks incomplete (an open brace/paren/bracket, an
+// unterminated string, or a trailing binary operator) switches to a
+// continuation prompt instead of handing a broken statement to the parser.
+func (r *REPL) Start(in io.Reader, out io.Writer) {
+	scanner := bufio.NewScanner(in)
+	var buffered strings.Builder
+
+	for {
+		if buffered.Len() == 0 {
+			fmt.Fprint(out, replPrompt)
+		} else {
+			fmt.Fprint(out, replContinuation)
+		}
+
+		if !scanner.Scan() {
+			return
+		}
+		line := scanner.Text()
+
+		if buffered.Len() == 0 {
+			if strings.TrimSpace(line) == ":exit" {
+				return
+			}
+			if r.handleMetaCommand(line, out) {
+				continue
+			}
+		}
+
+		buffered.WriteString(line)
+		buffered.WriteString("\n")
+
+		src := buffered.String()
+		if isIncompleteInput(src) {
+			continue
+		}
+		buffered.Reset()
+
+		r.appendHistory(strings.TrimRight(src, "\n"))
+		runProgram(r.evaluator, r.env, src, out)
+	}
+}
+
+// handleMetaCommand recognizes the `:`-prefixed REPL commands. It only
+// looks at a line when no multi-line statement is being buffered, so
+// ":load"/":type"/":reset" inside a string or block aren't misread.
+func (r *REPL) handleMetaCommand(line string, out io.Writer) bool {
+	trimmed := strings.TrimSpace(line)
+
+	switch {
+	case trimmed == ":reset":
+		r.env = NewEvalEnv()
+		fmt.Fprintln(out, "environment reset")
+		return true
+
+	case strings.HasPrefix(trimmed, ":load "):
+		path := strings.TrimSpace(strings.TrimPrefix(trimmed, ":load "))
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintln(out, "could not read file:", err)
+			return true
+		}
+		runProgram(r.evaluator, r.env, string(data), out)
+		return true
+
+	case strings.HasPrefix(trimmed, ":type "):
+		r.printType(strings.TrimSpace(strings.TrimPrefix(trimmed, ":type ")), out)
+		return true
+
+	case trimmed == ":history":
+		for _, line := range r.history {
+			fmt.Fprintln(out, line)
+		}
+		return true
+
+	default:
+		return false
+	}
+}
+
+// printType implements `:type <expr>`, reusing TypeOfExpression's own
+// type-determination logic rather than duplicating it.
+func (r *REPL) printType(exprSrc string, out io.Writer) {
+	p := NewParser(NewLexer(exprSrc))
+	expr := p.parseExpression(LOWEST)
+	if errs := p.Errors(); len(errs) != 0 {
+		printParseErrors(out, errs)
+		return
+	}
+
+	result := r.evaluator.typeof(&TypeOfExpression{Expr: expr}, r.env)
+	fmt.Fprintln(out, result.Inspect())
+}
+
+func (r *REPL) appendHistory(line string) {
+	r.history = append(r.history, line)
+
+	if r.histPath == "" {
+		return
+	}
+	f, err := os.OpenFile(r.histPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, line)
+}
+
+// runProgram parses and evaluates src against env, printing the result's
+// Inspect() (or any parser errors) to out. Shared by the REPL's own input
+// and :load; runFile in main.go does its own parsing and evaluation.
+func runProgram(eval *Evaluator, env *EvalEnv, src string, out io.Writer) {
+	program, errs := ParseProgram(src)
+	if len(errs) != 0 {
+		printParseErrors(out, errs)
+		return
+	}
+
+	result := eval.EvalProgram(program, env)
+	if result == nil {
+		return
+	}
+	if _, ok := result.(*Null); ok {
+		return
+	}
+	fmt.Fprintln(out, result.Inspect())
+}
+
+func printParseErrors(out io.Writer, errors []*ParseError) {
+	for _, err := range errors {
+		fmt.Fprintln(out, " -", err.String())
+	}
+}
+
+// isIncompleteInput reports whether src looks like a statement still
+// waiting on more input.
+func isIncompleteInput(src string) bool {
+	if strings.Count(src, `"`)%2 != 0 {
+		return true
+	}
+
+	depth := 0
+	var last Token
+
+	l := NewLexer(src)
+	for {
+		tok := l.NextToken()
+		if tok.Type == EOF {
+			break
+		}
+		switch tok.Type {
+		case LPAREN, LBRACE, LBRACKET:
+			depth++
+		case RPAREN, RBRACE, RBRACKET:
+			depth--
+		}
+		last = tok
+	}
+
+	if depth > 0 {
+		return true
+	}
+
+	switch last.Type {
+	case ADD, SUB, MUL, DIV, MOD, ASSIGN, EQ, NOT_EQ, LT, GT, LE, GE, AND, OR, COMMA:
+		return true
+	default:
+		return false
+	}
+}
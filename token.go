@@ -9,19 +9,23 @@ const (
 	EOF     TokenType = "EOF"
 
 	// Identifiers and literals
-	IDENT  TokenType = "IDENT" // main, foo, x, etc.
-	INT    TokenType = "INT"   // 123, 42
-	STRING TokenType = "STRING"
-	FLOAT  TokenType = "FLOAT"
-	BOOL   TokenType = "BOOL" // true, false
-	NIL    TokenType = "NIL"  // null, nil
+	IDENT   TokenType = "IDENT" // main, foo, x, etc.
+	INT     TokenType = "INT"   // 123, 42
+	STRING  TokenType = "STRING"
+	FLOAT   TokenType = "FLOAT"
+	BOOL    TokenType = "BOOL" // true, false
+	NIL     TokenType = "NIL"  // null, nil
+	COMMENT TokenType = "COMMENT"
 
 	// Delimiters
 	LPAREN    TokenType = "("
 	RPAREN    TokenType = ")"
 	LBRACE    TokenType = "{"
 	RBRACE    TokenType = "}"
+	LBRACKET  TokenType = "["
+	RBRACKET  TokenType = "]"
 	COMMA     TokenType = ","
+	COLON     TokenType = ":"
 	SEMICOLON TokenType = ";"
 
 	// Operators
@@ -58,12 +62,15 @@ const (
 	TYPEOF   TokenType = "typeof"
 	BREAK    TokenType = "break"
 	CONTINUE TokenType = "continue"
+	ON       TokenType = "on"
 )
 
 // Token represents a token with type and literal string value
 type Token struct {
 	Type    TokenType
 	Literal string
+	Line    int // 1-based line the token starts on
+	Col     int // 1-based column the token starts on
 }
 
 // Precedence constants
@@ -78,21 +85,23 @@ const (
 	PRODUCT         // *
 	PREFIX          // -X or !X
 	CALL            // myFunction(X)
+	INDEX           // array[0]
 )
 
 var precedences = map[TokenType]int{
-    OR:     OR_PREC,     // ||
-    AND:    AND_PREC,    // &&
-    EQ:     EQUALS,      // ==
-    NOT_EQ: EQUALS,      // !=
-    LT:     LESSGREATER, //
-    GT:     LESSGREATER, // >
-    LE:     LESSGREATER, // <=
-    GE:     LESSGREATER, // >=
-    ADD:    SUM,         // +
-    SUB:    SUM,         // -
-    MUL:    PRODUCT,     // *
-    DIV:    PRODUCT,     // /
-    MOD:    PRODUCT,     // %
-    LPAREN: CALL,        // (
+    OR:       OR_PREC,     // ||
+    AND:      AND_PREC,    // &&
+    EQ:       EQUALS,      // ==
+    NOT_EQ:   EQUALS,      // !=
+    LT:       LESSGREATER, //
+    GT:       LESSGREATER, // >
+    LE:       LESSGREATER, // <=
+    GE:       LESSGREATER, // >=
+    ADD:      SUM,         // +
+    SUB:      SUM,         // -
+    MUL:      PRODUCT,     // *
+    DIV:      PRODUCT,     // /
+    MOD:      PRODUCT,     // %
+    LPAREN:   CALL,        // (
+    LBRACKET: INDEX,       // [
 }
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TypeError reports a single type mismatch, tagged with the source position
+// of the expression or statement that triggered it, mirroring ParseError.
+type TypeError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *TypeError) String() string {
+	return fmt.Sprintf("type error at %d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// funcSignature records a declared function's parameter and return types so
+// CallExpression and ReturnStatement can be checked against it.
+type funcSignature struct {
+	ReturnType Type
+	Params     []Type
+}
+
+// TypeChecker walks a parsed Program recording the declared type of every
+// VariableDeclaration and inferring the type of every Expression, flagging
+// mismatches it finds along the way.
+type TypeChecker struct {
+	errors []*TypeError
+	funcs  map[string]*funcSignature
+}
+
+// Check type-checks program and returns every mismatch found, in the order
+// encountered. An empty (non-nil-or-nil, either is fine) slice means the
+// program type-checks cleanly.
+func Check(program *Program) []*TypeError {
+	tc := &TypeChecker{funcs: make(map[string]*funcSignature)}
+	tc.hoistFunctions(program.Statements)
+	tc.checkStatements(program.Statements, NewEnv(), nil)
+	return tc.errors
+}
+
+func (tc *TypeChecker) errorAt(tok Token, format string, a ...interface{}) {
+	tc.errors = append(tc.errors, &TypeError{
+		Line:    tok.Line,
+		Column:  tok.Col,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+// hoistFunctions registers every top-level function's signature before any
+// bodies are checked, so forward references and recursive calls resolve.
+func (tc *TypeChecker) hoistFunctions(stmts []Statement) {
+	for _, stmt := range stmts {
+		fs, ok := stmt.(*FunctionStatement)
+		if !ok {
+			continue
+		}
+		sig := &funcSignature{ReturnType: typeFromToken(fs.ReturnType)}
+		for _, p := range fs.Parameters {
+			sig.Params = append(sig.Params, typeFromToken(p.Type))
+		}
+		tc.funcs[fs.Name.Value] = sig
+	}
+}
+
+func (tc *TypeChecker) checkStatements(stmts []Statement, env *Env, fn *FunctionStatement) {
+	for _, stmt := range stmts {
+		tc.checkStatement(stmt, env, fn)
+	}
+}
+
+func (tc *TypeChecker) checkStatement(stmt Statement, env *Env, fn *FunctionStatement) {
+	switch s := stmt.(type) {
+	case *VariableDeclaration:
+		declared := typeFromToken(s.Token)
+		valType := tc.inferType(s.Value, env)
+		if !isAssignable(valType, declared) {
+			tc.errorAt(s.Token, "cannot assign %s to %s variable %q", valType, declared, s.Name.Value)
+		}
+		env.Set(s.Name.Value, declared)
+	case *ExpressionStatement:
+		tc.inferType(s.Expression, env)
+	case *ReturnStatement:
+		tc.checkReturn(s, env, fn)
+	case *BlockStatement:
+		tc.checkStatements(s.Statements, NewEnclosedTypeEnv(env), fn)
+	case *IfStatement:
+		tc.checkCondition(s.Condition, env)
+		tc.checkStatement(s.ThenBlock, env, fn)
+		for _, elif := range s.ElseIfs {
+			tc.checkCondition(elif.Condition, env)
+			tc.checkStatement(elif.Block, env, fn)
+		}
+		if s.ElseBlock != nil {
+			tc.checkStatement(s.ElseBlock, env, fn)
+		}
+	case *WhileStatement:
+		tc.checkCondition(s.Condition, env)
+		tc.checkStatement(s.Block, env, fn)
+	case *LoopStatement:
+		tc.checkStatement(s.Block, env, fn)
+	case *ForStatement:
+		tc.checkForStatement(s, env, fn)
+	case *FunctionStatement:
+		tc.checkFunctionStatement(s, env)
+	case *EventHandler:
+		inner := NewEnclosedTypeEnv(env)
+		for _, p := range s.Params {
+			inner.Set(p.Name.Value, typeFromToken(p.Type))
+		}
+		tc.checkStatements(s.Body.Statements, inner, nil)
+	}
+}
+
+func (tc *TypeChecker) checkFunctionStatement(fs *FunctionStatement, env *Env) {
+	inner := NewEnclosedTypeEnv(env)
+	for _, p := range fs.Parameters {
+		inner.Set(p.Name.Value, typeFromToken(p.Type))
+	}
+	tc.checkStatements(fs.Body.Statements, inner, fs)
+}
+
+// checkReturn checks that a return statement's value is assignable to the
+// enclosing function's declared return type. A bare "return;" is only
+// valid inside a void function.
+func (tc *TypeChecker) checkReturn(rs *ReturnStatement, env *Env, fn *FunctionStatement) {
+	if fn == nil {
+		return
+	}
+	want := typeFromToken(fn.ReturnType)
+	if rs.ReturnValue == nil {
+		if want != VoidType {
+			tc.errorAt(rs.Token, "missing return value in function %q returning %s", fn.Name.Value, want)
+		}
+		return
+	}
+	got := tc.inferType(rs.ReturnValue, env)
+	if !isAssignable(got, want) {
+		tc.errorAt(rs.Token, "cannot return %s from function %q returning %s", got, fn.Name.Value, want)
+	}
+}
+
+// checkCondition type-checks a condition's sub-expressions. It does not
+// flag the condition's own type: the evaluator's isTruthy (evaluator.go)
+// deliberately accepts any value (nonzero ints included, only nil/false/0
+// are falsy), so there is no type here that's actually wrong at runtime.
+func (tc *TypeChecker) checkCondition(cond Expression, env *Env) {
+	tc.inferType(cond, env)
+}
+
+// checkForStatement checks that Iterable is one of the types the evaluator's
+// toIterator actually supports (int, string, array, hash), then binds
+// Identifier to the element type it yields inside the loop body.
+func (tc *TypeChecker) checkForStatement(fs *ForStatement, env *Env, fn *FunctionStatement) {
+	iterType := tc.inferType(fs.Iterable, env)
+	elemType := UnknownType
+	if iterType != UnknownType {
+		et, ok := elementTypeOf(iterType)
+		if !ok {
+			tc.errorAt(tokenOf(fs.Iterable), "cannot iterate over %s", iterType)
+		} else {
+			elemType = et
+		}
+	}
+
+	inner := NewEnclosedTypeEnv(env)
+	if fs.Identifier != nil {
+		inner.Set(fs.Identifier.Value, elemType)
+	}
+	tc.checkStatement(fs.Block, inner, fn)
+}
+
+// elementTypeOf returns the type a for-loop binds its identifier to when
+// iterating a value of type t, matching the evaluator's toIterator.
+func elementTypeOf(t Type) (Type, bool) {
+	switch {
+	case t == IntType:
+		return IntType, true
+	case t == StringType:
+		return StringType, true
+	case t == HashType:
+		return UnknownType, true // keys may be any hashable type
+	case strings.HasSuffix(string(t), "[]"):
+		return Type(strings.TrimSuffix(string(t), "[]")), true
+	default:
+		return UnknownType, false
+	}
+}
+
+// inferType computes the static type of expr, recording any mismatch it
+// finds along the way (e.g. inside an InfixExpression's operands).
+func (tc *TypeChecker) inferType(expr Expression, env *Env) Type {
+	switch e := expr.(type) {
+	case *IntegerLiteral:
+		return IntType
+	case *FloatLiteral:
+		return FloatType
+	case *StringLiteral:
+		return StringType
+	case *BooleanLiteral:
+		return BoolType
+	case *NilLiteral:
+		return NilType
+	case *VoidLiteral:
+		return VoidType
+	case *Identifier:
+		if t, ok := env.Get(e.Value); ok {
+			return t
+		}
+		return UnknownType
+	case *PrefixExpression:
+		return tc.inferPrefixType(e, env)
+	case *InfixExpression:
+		return tc.inferInfixType(e, env)
+	case *TypeOfExpression:
+		tc.inferType(e.Expr, env)
+		return StringType
+	case *CallExpression:
+		return tc.inferCallType(e, env)
+	case *ArrayLiteral:
+		return tc.inferArrayType(e, env)
+	case *IndexExpression:
+		return tc.inferIndexType(e, env)
+	case *HashLiteral:
+		for k, v := range e.Pairs {
+			tc.inferType(k, env)
+			tc.inferType(v, env)
+		}
+		return HashType
+	case *FunctionLiteral:
+		return FuncType
+	default:
+		return UnknownType
+	}
+}
+
+func (tc *TypeChecker) inferPrefixType(pe *PrefixExpression, env *Env) Type {
+	right := tc.inferType(pe.Right, env)
+	switch pe.Operator {
+	case "-":
+		if right != IntType && right != FloatType && right != UnknownType {
+			tc.errorAt(pe.Token, "operator %s not defined for %s", pe.Operator, right)
+			return UnknownType
+		}
+		return right
+	case "!":
+		if right != BoolType && right != UnknownType {
+			tc.errorAt(pe.Token, "operator %s not defined for %s", pe.Operator, right)
+		}
+		return BoolType
+	default:
+		return UnknownType
+	}
+}
+
+func (tc *TypeChecker) inferInfixType(ie *InfixExpression, env *Env) Type {
+	left := tc.inferType(ie.Left, env)
+	right := tc.inferType(ie.Right, env)
+
+	switch ie.Operator {
+	case "&&", "||":
+		return BoolType
+	case "==", "!=":
+		return BoolType
+	case "<", ">", "<=", ">=":
+		// Ordering is only defined for numeric operands in the evaluator
+		// (evalIntegerInfixExpression / evalNumericInfixExpression); bool
+		// and string comparisons other than ==/!= fail at runtime.
+		numericResult(tc, ie, left, right)
+		return BoolType
+	case "+":
+		if left == StringType || right == StringType {
+			return StringType
+		}
+		return numericResult(tc, ie, left, right)
+	case "-", "*", "/", "%":
+		return numericResult(tc, ie, left, right)
+	default:
+		return UnknownType
+	}
+}
+
+// numericResult applies int+float promotion for arithmetic operators,
+// flagging operands that aren't numeric at all.
+func numericResult(tc *TypeChecker, ie *InfixExpression, left, right Type) Type {
+	if left == UnknownType || right == UnknownType {
+		return UnknownType
+	}
+	if left != IntType && left != FloatType {
+		tc.errorAt(ie.Token, "operator %s not defined for %s", ie.Operator, left)
+		return UnknownType
+	}
+	if right != IntType && right != FloatType {
+		tc.errorAt(ie.Token, "operator %s not defined for %s", ie.Operator, right)
+		return UnknownType
+	}
+	if left == FloatType || right == FloatType {
+		return FloatType
+	}
+	return IntType
+}
+
+func (tc *TypeChecker) inferCallType(ce *CallExpression, env *Env) Type {
+	for _, arg := range ce.Arguments {
+		tc.inferType(arg, env)
+	}
+	name, ok := ce.Function.(*Identifier)
+	if !ok {
+		return UnknownType
+	}
+	sig, ok := tc.funcs[name.Value]
+	if !ok {
+		return UnknownType
+	}
+	return sig.ReturnType
+}
+
+func (tc *TypeChecker) inferArrayType(al *ArrayLiteral, env *Env) Type {
+	if len(al.Elements) == 0 {
+		return ArrayOf(UnknownType)
+	}
+	elem := tc.inferType(al.Elements[0], env)
+	for _, e := range al.Elements[1:] {
+		t := tc.inferType(e, env)
+		if !isAssignable(t, elem) && !isAssignable(elem, t) {
+			tc.errorAt(tokenOf(e), "array element type mismatch: %s vs %s", t, elem)
+		}
+	}
+	return ArrayOf(elem)
+}
+
+func (tc *TypeChecker) inferIndexType(ie *IndexExpression, env *Env) Type {
+	left := tc.inferType(ie.Left, env)
+	tc.inferType(ie.Index, env)
+	if left == UnknownType {
+		return UnknownType
+	}
+	elem, ok := elementTypeOf(left)
+	if !ok {
+		tc.errorAt(ie.Token, "cannot index into %s", left)
+		return UnknownType
+	}
+	return elem
+}
+
+// isAssignable reports whether a value of type from can be used where a
+// value of type to is expected, allowing int->float promotion and treating
+// UnknownType as a wildcard in either position.
+func isAssignable(from, to Type) bool {
+	if from == to || from == UnknownType || to == UnknownType {
+		return true
+	}
+	if from == IntType && to == FloatType {
+		return true
+	}
+	return false
+}
+
+// typeFromToken converts a parser type token (e.g. "int", "int[]", "void")
+// into a Type, preserving array parameterization.
+func typeFromToken(tok Token) Type {
+	lit := tok.Literal
+	if strings.HasSuffix(lit, "[]") {
+		return ArrayOf(typeFromToken(Token{Literal: strings.TrimSuffix(lit, "[]")}))
+	}
+	switch lit {
+	case "int":
+		return IntType
+	case "string":
+		return StringType
+	case "float":
+		return FloatType
+	case "void":
+		return VoidType
+	case "bool":
+		return BoolType
+	default:
+		return UnknownType
+	}
+}
+
+// tokenOf returns the position-bearing token for expr, falling back to a
+// zero Token (line/col 0) for node types that don't carry one directly;
+// Expression has no uniform position accessor in this AST.
+func tokenOf(expr Expression) Token {
+	switch e := expr.(type) {
+	case *Identifier:
+		return e.Token
+	case *IntegerLiteral:
+		return e.Token
+	case *FloatLiteral:
+		return e.Token
+	case *StringLiteral:
+		return e.Token
+	case *BooleanLiteral:
+		return e.Token
+	case *NilLiteral:
+		return e.Token
+	case *VoidLiteral:
+		return e.Token
+	case *PrefixExpression:
+		return e.Token
+	case *InfixExpression:
+		return e.Token
+	case *TypeOfExpression:
+		return e.Token
+	case *CallExpression:
+		return e.Token
+	case *ArrayLiteral:
+		return e.Token
+	case *IndexExpression:
+		return e.Token
+	case *HashLiteral:
+		return e.Token
+	case *FunctionLiteral:
+		return e.Token
+	default:
+		return Token{}
+	}
+}
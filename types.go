@@ -8,28 +8,53 @@ const (
 	StringType  Type = "string"
 	VoidType    Type = "void"
 	FloatType   Type = "float"
+	FuncType    Type = "function"
+	ArrayType   Type = "array"
+	HashType    Type = "hash"
+	BoolType    Type = "bool"
+	NilType     Type = "nil"
 	UnknownType Type = "unknown" // fallback
 )
 
-// Env represents the type environment for type checking
+// ArrayOf returns the parameterized array type for a given element type,
+// e.g. ArrayOf(IntType) == Type("int[]"), matching how the parser spells
+// array-typed declarations and parameters.
+func ArrayOf(elem Type) Type {
+	return Type(string(elem) + "[]")
+}
+
+// Env represents a lexically scoped type environment for type checking,
+// mirroring EvalEnv's outer-chain closure pattern.
 type Env struct {
 	types map[string]Type
+	Outer *Env
 }
 
-// NewEnv creates a new type environment
+// NewEnv creates a new, top-level type environment.
 func NewEnv() *Env {
 	return &Env{types: make(map[string]Type)}
 }
 
-// Get retrieves a type for a given name
-func (e *Env) Get(name string) Type {
+// NewEnclosedTypeEnv creates a type environment nested inside outer, used
+// for block scopes and function bodies.
+func NewEnclosedTypeEnv(outer *Env) *Env {
+	env := NewEnv()
+	env.Outer = outer
+	return env
+}
+
+// Get retrieves a type for a given name, searching outer scopes if needed.
+func (e *Env) Get(name string) (Type, bool) {
 	if t, ok := e.types[name]; ok {
-		return t
+		return t, true
+	}
+	if e.Outer != nil {
+		return e.Outer.Get(name)
 	}
-	return UnknownType
+	return UnknownType, false
 }
 
-// Set stores a type for a given name
+// Set stores a type for a given name in the current scope.
 func (e *Env) Set(name string, t Type) {
 	e.types[name] = t
 }